@@ -0,0 +1,164 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package physical
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// alterAllReplicationJobsHeader is the header of the results of ALTER VIRTUAL
+// CLUSTER ALL PAUSE/RESUME REPLICATION, which returns a row per tenant that
+// has a replication consumer job.
+var alterAllReplicationJobsHeader = colinfo.ResultColumns{
+	{Name: "id", Typ: types.Int},
+	{Name: "name", Typ: types.String},
+	{Name: "job_id", Typ: types.Int},
+	{Name: "result", Typ: types.String},
+}
+
+func alterAllReplicationJobsTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	alterStmt, ok := stmt.(*tree.AlterTenantReplication)
+	if !ok || !alterStmt.TenantSpec.All {
+		return false, nil, nil
+	}
+	return true, alterAllReplicationJobsHeader, nil
+}
+
+// alterAllReplicationJobsHook implements ALTER VIRTUAL CLUSTER ALL PAUSE
+// REPLICATION and ALTER VIRTUAL CLUSTER ALL RESUME REPLICATION, which pause or
+// resume the replication consumer job of every tenant in a single transaction.
+func alterAllReplicationJobsHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, []sql.PlanNode, bool, error) {
+	alterStmt, ok := stmt.(*tree.AlterTenantReplication)
+	if !ok || !alterStmt.TenantSpec.All {
+		return nil, nil, nil, false, nil
+	}
+
+	if !p.ExecCfg().Codec.ForSystemTenant() {
+		return nil, nil, nil, false, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only the system tenant can alter tenant")
+	}
+	if alterStmt.Command != tree.PauseJob && alterStmt.Command != tree.ResumeJob {
+		return nil, nil, nil, false, errors.AssertionFailedf(
+			"unexpected statement for all virtual clusters: %s", alterStmt)
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		if err := utilccl.CheckEnterpriseEnabled(
+			p.ExecCfg().Settings,
+			alterReplicationJobOp,
+		); err != nil {
+			return err
+		}
+
+		if err := sql.CanManageTenant(ctx, p); err != nil {
+			return err
+		}
+
+		tenInfos, err := replicatingTenants(ctx, p)
+		if err != nil {
+			return err
+		}
+		jobRegistry := p.ExecCfg().JobRegistry
+		pts := protectedTimestampStorage(p)
+		for _, tenInfo := range tenInfos {
+			// A tenant may still reference a job that has since failed or was
+			// canceled, which can be neither paused nor resumed. It is skipped
+			// rather than failing the statement for every other tenant.
+			job, err := jobRegistry.LoadJobWithTxn(ctx, tenInfo.PhysicalReplicationConsumerJobID, p.InternalSQLTxn())
+			if err != nil {
+				return errors.Wrapf(err, "loading replication job %d for tenant %q",
+					tenInfo.PhysicalReplicationConsumerJobID, tenInfo.Name)
+			}
+			if status := job.Status(); status.Terminal() {
+				resultsCh <- tree.Datums{
+					tree.NewDInt(tree.DInt(tenInfo.ID)),
+					tree.NewDString(string(tenInfo.Name)),
+					tree.NewDInt(tree.DInt(tenInfo.PhysicalReplicationConsumerJobID)),
+					tree.NewDString(alterAllReplicationJobsResult(alterStmt.Command, true /* skipped */, status)),
+				}
+				continue
+			}
+
+			skipped, status, err := setTenantJobState(
+				ctx, p.InternalSQLTxn(), jobRegistry, pts, alterStmt.Command, false /* refreshTopology */, tenInfo)
+			if err != nil {
+				return errors.Wrapf(err, "altering replication job %d for tenant %q",
+					tenInfo.PhysicalReplicationConsumerJobID, tenInfo.Name)
+			}
+			resultsCh <- tree.Datums{
+				tree.NewDInt(tree.DInt(tenInfo.ID)),
+				tree.NewDString(string(tenInfo.Name)),
+				tree.NewDInt(tree.DInt(tenInfo.PhysicalReplicationConsumerJobID)),
+				tree.NewDString(alterAllReplicationJobsResult(alterStmt.Command, skipped, status)),
+			}
+		}
+		return nil
+	}
+	return fn, alterAllReplicationJobsHeader, nil, false, nil
+}
+
+// replicatingTenants returns the info of every non-dropped tenant that has a
+// replication consumer job, ordered by tenant ID.
+func replicatingTenants(ctx context.Context, p sql.PlanHookState) ([]*mtinfopb.TenantInfo, error) {
+	txn := p.InternalSQLTxn()
+	settings := p.ExecCfg().Settings
+	ids, err := sql.GetAllNonDropTenantIDs(ctx, txn, settings)
+	if err != nil {
+		return nil, err
+	}
+	var tenInfos []*mtinfopb.TenantInfo
+	for _, id := range ids {
+		tenInfo, err := sql.GetTenantRecordByID(ctx, txn, id, settings)
+		if err != nil {
+			return nil, err
+		}
+		if tenInfo.PhysicalReplicationConsumerJobID == 0 {
+			continue
+		}
+		tenInfos = append(tenInfos, tenInfo)
+	}
+	return tenInfos, nil
+}
+
+// alterAllReplicationJobsResult describes what a pause or resume did to a
+// single tenant's replication job.
+func alterAllReplicationJobsResult(
+	command tree.JobCommand, skipped bool, status jobs.Status,
+) string {
+	if skipped && status.Terminal() {
+		return fmt.Sprintf("skipped: job is %s", status)
+	}
+	if skipped {
+		return fmt.Sprintf("skipped: job is already %s", status)
+	}
+	if command == tree.PauseJob {
+		return "pause requested"
+	}
+	return "resumed"
+}
+
+func init() {
+	sql.AddPlanHook("alter all replication jobs", alterAllReplicationJobsHook, alterAllReplicationJobsTypeCheck)
+}
@@ -10,32 +10,52 @@ package physical
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster"
 	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster/replicationutils"
 	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster/streamclient"
 	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/asof"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	jsonb "github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 )
 
@@ -48,39 +68,234 @@ var alterReplicationCutoverHeader = colinfo.ResultColumns{
 	{Name: "cutover_time", Typ: types.Decimal},
 }
 
+// alterReplicationCutoverJSONHeader is the header of the results of ALTER
+// VIRTUAL CLUSTER ... COMPLETE REPLICATION ... WITH FORMAT = JSON.
+var alterReplicationCutoverJSONHeader = colinfo.ResultColumns{
+	{Name: "cutover", Typ: types.Jsonb},
+}
+
+// cutoverFormatJSON is the FORMAT cutover option selecting
+// alterReplicationCutoverJSONHeader.
+const cutoverFormatJSON = "JSON"
+
+// cutoverResultHeader returns the header of the results of a cutover with the
+// given options.
+func cutoverResultHeader(options tree.ReplicationCutoverOptions) colinfo.ResultColumns {
+	if options.Format == cutoverFormatJSON {
+		return alterReplicationCutoverJSONHeader
+	}
+	return alterReplicationCutoverHeader
+}
+
+// cutoverJSONDatum describes a cutover of the given tenant's replication job as
+// the row of alterReplicationCutoverJSONHeader. The cutover time is rendered as
+// a number, with the same value as the decimal returned by default.
+func cutoverJSONDatum(
+	cutoverTime hlc.Timestamp, latest bool, tenInfo *mtinfopb.TenantInfo,
+) tree.Datum {
+	b := jsonb.NewObjectBuilder(4)
+	b.Add("cutover_time", jsonb.FromDecimal(eval.TimestampToDecimal(cutoverTime)))
+	b.Add("latest", jsonb.FromBool(latest))
+	b.Add("tenant_id", jsonb.FromInt64(int64(tenInfo.ID)))
+	b.Add("job_id", jsonb.FromInt64(int64(tenInfo.PhysicalReplicationConsumerJobID)))
+	return tree.NewDJSON(b.Build())
+}
+
+// alterReplicationValidateOnlyHeader is the header of the results of ALTER
+// VIRTUAL CLUSTER ... START REPLICATION ... WITH VALIDATE_ONLY.
+var alterReplicationValidateOnlyHeader = colinfo.ResultColumns{
+	{Name: "resume_timestamp", Typ: types.Decimal},
+	{Name: "source_id", Typ: types.String},
+}
+
+// alterReplicationLastRevertHeader is the header of the results of ALTER
+// VIRTUAL CLUSTER ... SHOW LAST REVERT.
+var alterReplicationLastRevertHeader = colinfo.ResultColumns{
+	{Name: "last_revert_timestamp", Typ: types.Decimal},
+}
+
+// alterReplicationTestSourceHeader is the header of the results of ALTER
+// VIRTUAL CLUSTER ... TEST REPLICATION SOURCE.
+var alterReplicationTestSourceHeader = colinfo.ResultColumns{
+	{Name: "status", Typ: types.String},
+	{Name: "source_id", Typ: types.String},
+	{Name: "error", Typ: types.String},
+}
+
+// The statuses reported by ALTER VIRTUAL CLUSTER ... TEST REPLICATION SOURCE.
+const (
+	// replicationSourceReachable is reported if the source was reached and
+	// still identifies the source tenant as the one being replicated.
+	replicationSourceReachable = "reachable"
+	// replicationSourceUnreachable is reported if the source could not be
+	// reached, or failed to report the details of the source tenant.
+	replicationSourceUnreachable = "unreachable"
+	// replicationSourceMismatched is reported if the source was reached, but
+	// the source tenant is no longer the one the job was replicating.
+	replicationSourceMismatched = "mismatched"
+)
+
 // ResolvedTenantReplicationOptions represents options from an
 // evaluated CREATE/ALTER VIRTUAL CLUSTER FROM REPLICATION command.
-type resolvedTenantReplicationOptions struct {
-	resumeTimestamp  hlc.Timestamp
-	retention        *int32
-	expirationWindow *time.Duration
+type ResolvedTenantReplicationOptions struct {
+	resumeTimestamp     hlc.Timestamp
+	retention           *int32
+	initialRetention    *int32
+	expirationWindow    *time.Duration
+	seedFromBackup      *string
+	priority            *string
+	resumePartitions    map[string]hlc.Timestamp
+	owner               *string
+	serviceMode         *mtinfopb.TenantServiceMode
+	pauseOnDiskFull     *int32
+	maxPartitionStreams *int32
+	ptsAdvanceInterval  *time.Duration
+	verifyChecksums     *bool
+	eventSink           *string
+	targetNodes         []roachpb.NodeID
+	onError             *string
+	autoCutover         *hlc.Timestamp
+	idleTimeout         *time.Duration
+	spanConfigMode      *string
+	encryptionKey       *string
+	checkpointInterval  *time.Duration
+	sourceConnPool      *int32
+	ingestMode          *string
+}
+
+// replicationPriorities are the values accepted by the PRIORITY option.
+var replicationPriorities = []string{"low", "normal", "high"}
+
+// replicationOnErrorDispositions are the values accepted by the ON_ERROR
+// option.
+var replicationOnErrorDispositions = []string{onErrorPause, onErrorFail}
+
+const (
+	onErrorPause = "pause"
+	onErrorFail  = "fail"
+)
+
+// replicationSpanConfigModes are the values accepted by the SPAN_CONFIG
+// option.
+var replicationSpanConfigModes = []string{spanConfigModeInherit, spanConfigModeLocal}
+
+const (
+	spanConfigModeInherit = "inherit"
+	spanConfigModeLocal   = "local"
+)
+
+// replicationIngestModes are the values accepted by the INGEST_MODE option.
+var replicationIngestModes = []string{ingestModeRaw, ingestModeRow}
+
+const (
+	ingestModeRaw = "raw"
+	ingestModeRow = "row"
+)
+
+// encryptionKeySchemes are the URI schemes of the key references accepted by
+// the ENCRYPTION_KEY option.
+var encryptionKeySchemes = []string{"kms", "aws-kms", "gcp-kms", "azure-kms"}
+
+// eventSinkSchemes are the URI schemes accepted by the EVENT_SINK option.
+var eventSinkSchemes = []string{"kafka", "webhook-https"}
+
+// replicationServiceModesOnComplete are the values accepted by the
+// SERVICE_MODE_ON_COMPLETE option.
+var replicationServiceModesOnComplete = []mtinfopb.TenantServiceMode{
+	mtinfopb.ServiceModeShared, mtinfopb.ServiceModeExternal,
+}
+
+// maxReplicationOwnerLength bounds the length of the OWNER option.
+const maxReplicationOwnerLength = 128
+
+// ZoneGCTTLResolver returns the GC TTL, in seconds, configured for the given
+// named zone. It is used to evaluate RETENTION = FROM ZONE.
+type ZoneGCTTLResolver func(ctx context.Context, zone zonepb.NamedZone) (int32, error)
+
+// planZoneGCTTLResolver returns a ZoneGCTTLResolver that reads the zone
+// configuration in the planner's transaction.
+func planZoneGCTTLResolver(p sql.PlanHookState) ZoneGCTTLResolver {
+	return func(ctx context.Context, zone zonepb.NamedZone) (int32, error) {
+		txn := p.InternalSQLTxn()
+		zc, err := sql.GetHydratedZoneConfigForNamedZone(ctx, txn.KV(), txn.Descriptors(), zone)
+		if err != nil {
+			return 0, err
+		}
+		return zc.GC.TTLSeconds, nil
+	}
 }
 
+// evalTenantReplicationOptions evaluates the given options in the context of
+// the planner.
 func evalTenantReplicationOptions(
+	ctx context.Context, p sql.PlanHookState, options tree.TenantReplicationOptions, op string,
+) (*ResolvedTenantReplicationOptions, error) {
+	r, err := EvalTenantReplicationOptions(ctx, options, p.ExprEvaluator(op),
+		&p.ExtendedEvalContext().Context, p.SemaCtx(), planZoneGCTTLResolver(p), op)
+	if err != nil {
+		return nil, err
+	}
+	if nodeIDs, ok := r.GetTargetNodes(); ok {
+		if err := validateTargetNodes(nodeIDs, p.ExecCfg().NodeDescs.GetNodeDescriptor); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// EvalTenantReplicationOptions evaluates and validates the options of a
+// CREATE/ALTER VIRTUAL CLUSTER FROM REPLICATION command. The GC TTL of the
+// zone named by RETENTION = FROM ZONE, if any, is resolved using zoneGCTTL.
+func EvalTenantReplicationOptions(
 	ctx context.Context,
 	options tree.TenantReplicationOptions,
 	eval exprutil.Evaluator,
 	evalCtx *eval.Context,
 	semaCtx *tree.SemaContext,
+	zoneGCTTL ZoneGCTTLResolver,
 	op string,
-) (*resolvedTenantReplicationOptions, error) {
-	r := &resolvedTenantReplicationOptions{}
+) (*ResolvedTenantReplicationOptions, error) {
+	r := &ResolvedTenantReplicationOptions{}
 	if options.Retention != nil {
-		dur, err := eval.Duration(ctx, options.Retention)
+		retSeconds, err := evalRetentionSeconds(ctx, eval, options.Retention, "retention")
+		if err != nil {
+			return nil, err
+		}
+		r.retention = &retSeconds
+	}
+	if options.RetentionFromZone != nil {
+		zoneName, err := eval.String(ctx, options.RetentionFromZone)
 		if err != nil {
 			return nil, err
 		}
-		retSeconds64, ok := dur.AsInt64()
-		if !ok {
-			return nil, errors.Newf("interval conversion error: %v", dur)
+		zone := zonepb.NamedZone(zoneName)
+		if _, ok := zonepb.NamedZones[zone]; !ok {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid RETENTION: unknown zone %q", zoneName)
 		}
-		if retSeconds64 > math.MaxInt32 || retSeconds64 < 0 {
-			return nil, errors.Newf("retention should result in a number of seconds between 0 and %d",
-				math.MaxInt32)
+		retSeconds, err := zoneGCTTL(ctx, zone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving GC TTL of zone %q", zoneName)
 		}
-		retSeconds := int32(retSeconds64)
 		r.retention = &retSeconds
 	}
+	if r.retention != nil {
+		minRetention := crosscluster.MinReplicationRetention.Get(&evalCtx.Settings.SV)
+		if err := validateMinRetention(*r.retention, minRetention); err != nil {
+			return nil, err
+		}
+	}
+	if options.InitialRetention != nil {
+		retSeconds, err := evalRetentionSeconds(ctx, eval, options.InitialRetention, "initial retention")
+		if err != nil {
+			return nil, err
+		}
+		r.initialRetention = &retSeconds
+	}
+	if r.retention != nil && r.initialRetention != nil {
+		if err := validateInitialRetention(*r.initialRetention, *r.retention); err != nil {
+			return nil, err
+		}
+	}
 	if options.ExpirationWindow != nil {
 		dur, err := eval.Duration(ctx, options.ExpirationWindow)
 		if err != nil {
@@ -89,94 +304,803 @@ func evalTenantReplicationOptions(
 		expirationWindow := time.Duration(dur.Nanos())
 		r.expirationWindow = &expirationWindow
 	}
+	if options.SeedFromBackup != nil {
+		uri, err := eval.String(ctx, options.SeedFromBackup)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateSeedFromBackupURI(uri); err != nil {
+			return nil, err
+		}
+		r.seedFromBackup = &uri
+	}
+	if options.Priority != nil {
+		priority, err := eval.String(ctx, options.Priority)
+		if err != nil {
+			return nil, err
+		}
+		priority = strings.ToLower(priority)
+		if !slices.Contains(replicationPriorities, priority) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid PRIORITY %q: must be one of %s",
+				priority, strings.Join(replicationPriorities, ", "))
+		}
+		r.priority = &priority
+	}
+	if options.ResumePartitions != nil {
+		encoded, err := eval.String(ctx, options.ResumePartitions)
+		if err != nil {
+			return nil, err
+		}
+		partitions, err := parseResumePartitions(encoded)
+		if err != nil {
+			return nil, err
+		}
+		r.resumePartitions = partitions
+	}
+	if options.Owner != nil {
+		owner, err := eval.String(ctx, options.Owner)
+		if err != nil {
+			return nil, err
+		}
+		if owner == "" {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "invalid OWNER: cannot be empty")
+		}
+		if len(owner) > maxReplicationOwnerLength {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid OWNER: must be at most %d bytes long",
+				maxReplicationOwnerLength)
+		}
+		r.owner = &owner
+	}
+	if options.ServiceModeOnComplete != nil {
+		modeName, err := eval.String(ctx, options.ServiceModeOnComplete)
+		if err != nil {
+			return nil, err
+		}
+		mode, ok := mtinfopb.TenantServiceModeValues[strings.ToLower(modeName)]
+		if !ok || !slices.Contains(replicationServiceModesOnComplete, mode) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid SERVICE_MODE_ON_COMPLETE %q: must be one of %s, %s",
+				modeName, mtinfopb.ServiceModeShared, mtinfopb.ServiceModeExternal)
+		}
+		r.serviceMode = &mode
+	}
+	if options.PauseOnDiskFull != nil {
+		percent, err := eval.Int(ctx, options.PauseOnDiskFull)
+		if err != nil {
+			return nil, err
+		}
+		if percent < 0 || percent > 100 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid PAUSE_ON_DISK_FULL %d: must be a percentage between 0 and 100", percent)
+		}
+		percent32 := int32(percent)
+		r.pauseOnDiskFull = &percent32
+	}
+	if options.MaxPartitionStreams != nil {
+		streams, err := eval.Int(ctx, options.MaxPartitionStreams)
+		if err != nil {
+			return nil, err
+		}
+		if streams <= 0 || streams > math.MaxInt32 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid MAX_PARTITION_STREAMS %d: must be between 1 and %d", streams, math.MaxInt32)
+		}
+		streams32 := int32(streams)
+		r.maxPartitionStreams = &streams32
+	}
+	if options.PTSAdvanceInterval != nil {
+		dur, err := eval.Duration(ctx, options.PTSAdvanceInterval)
+		if err != nil {
+			return nil, err
+		}
+		interval := time.Duration(dur.Nanos())
+		if interval <= 0 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid PTS_ADVANCE_INTERVAL %s: must be a positive duration", dur)
+		}
+		r.ptsAdvanceInterval = &interval
+	}
+	if options.VerifyChecksums != nil {
+		verify, err := eval.Bool(ctx, options.VerifyChecksums)
+		if err != nil {
+			return nil, err
+		}
+		r.verifyChecksums = &verify
+	}
+	if options.EventSink != nil {
+		uri, err := eval.String(ctx, options.EventSink)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateEventSinkURI(uri); err != nil {
+			return nil, err
+		}
+		r.eventSink = &uri
+	}
+	if options.TargetNodes != nil {
+		nodes, err := eval.String(ctx, options.TargetNodes)
+		if err != nil {
+			return nil, err
+		}
+		r.targetNodes, err = parseTargetNodes(nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.OnError != nil {
+		onError, err := eval.String(ctx, options.OnError)
+		if err != nil {
+			return nil, err
+		}
+		onError = strings.ToLower(onError)
+		if !slices.Contains(replicationOnErrorDispositions, onError) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid ON_ERROR %q: must be one of %s",
+				onError, strings.Join(replicationOnErrorDispositions, ", "))
+		}
+		r.onError = &onError
+	}
+	if options.SpanConfig != nil {
+		mode, err := eval.String(ctx, options.SpanConfig)
+		if err != nil {
+			return nil, err
+		}
+		mode = strings.ToLower(mode)
+		if !slices.Contains(replicationSpanConfigModes, mode) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid SPAN_CONFIG %q: must be one of %s",
+				mode, strings.Join(replicationSpanConfigModes, ", "))
+		}
+		r.spanConfigMode = &mode
+	}
+	if options.EncryptionKey != nil {
+		key, err := eval.String(ctx, options.EncryptionKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateEncryptionKey(key); err != nil {
+			return nil, err
+		}
+		r.encryptionKey = &key
+	}
+	if options.AutoCutover != nil {
+		ts, err := asof.EvalSystemTimeExpr(ctx, evalCtx, semaCtx, options.AutoCutover, op, asof.ReplicationCutover)
+		if err != nil {
+			return nil, err
+		}
+		if ts == hlc.MaxTimestamp {
+			// AUTO_CUTOVER = NULL clears a previously scheduled cutover.
+			ts = hlc.Timestamp{}
+		} else if err := validateAutoCutover(ts, evalCtx.GetStmtTimestamp()); err != nil {
+			return nil, err
+		}
+		r.autoCutover = &ts
+	}
+	if options.IdleTimeout != nil {
+		dur, err := eval.Duration(ctx, options.IdleTimeout)
+		if err != nil {
+			return nil, err
+		}
+		timeout := time.Duration(dur.Nanos())
+		if timeout <= 0 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid IDLE_TIMEOUT %s: must be a positive duration", dur)
+		}
+		r.idleTimeout = &timeout
+	}
+	if options.CheckpointInterval != nil {
+		dur, err := eval.Duration(ctx, options.CheckpointInterval)
+		if err != nil {
+			return nil, err
+		}
+		interval := time.Duration(dur.Nanos())
+		if err := validateCheckpointInterval(interval); err != nil {
+			return nil, err
+		}
+		r.checkpointInterval = &interval
+	}
+	if options.SourceConnPool != nil {
+		size, err := eval.Int(ctx, options.SourceConnPool)
+		if err != nil {
+			return nil, err
+		}
+		if size <= 0 || size > maxSourceConnPool {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid SOURCE_CONN_POOL %d: must be between 1 and %d", size, maxSourceConnPool)
+		}
+		size32 := int32(size)
+		r.sourceConnPool = &size32
+	}
+	if options.IngestMode != nil {
+		mode, err := eval.String(ctx, options.IngestMode)
+		if err != nil {
+			return nil, err
+		}
+		mode = strings.ToLower(mode)
+		if !slices.Contains(replicationIngestModes, mode) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid INGEST_MODE %q: must be one of %s",
+				mode, strings.Join(replicationIngestModes, ", "))
+		}
+		r.ingestMode = &mode
+	}
 	return r, nil
 }
 
-func (r *resolvedTenantReplicationOptions) GetRetention() (int32, bool) {
-	if r == nil || r.retention == nil {
-		return 0, false
+// evalRetentionSeconds evaluates a retention option, named by what in errors,
+// to a whole number of seconds.
+func evalRetentionSeconds(
+	ctx context.Context, eval exprutil.Evaluator, expr tree.Expr, what string,
+) (int32, error) {
+	dur, err := eval.Duration(ctx, expr)
+	if err != nil {
+		return 0, err
 	}
-	return *r.retention, true
+	seconds, ok := dur.AsInt64()
+	if !ok {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue, "interval conversion error: %v", dur)
+	}
+	if seconds > math.MaxInt32 || seconds < 0 {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s should result in a number of seconds between 0 and %d", what, math.MaxInt32)
+	}
+	return int32(seconds), nil
 }
 
-func (r *resolvedTenantReplicationOptions) GetExpirationWindow() (time.Duration, bool) {
-	if r == nil || r.expirationWindow == nil {
-		return 0, false
+// validateMinRetention checks that a retention of retSeconds is at least the
+// minimum retention configured for the cluster.
+func validateMinRetention(retSeconds int32, minRetention time.Duration) error {
+	retention := time.Duration(retSeconds) * time.Second
+	if retention < minRetention {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"retention %s is below the minimum of %s set by %s",
+			retention, minRetention, crosscluster.MinReplicationRetention.Name())
 	}
-	return *r.expirationWindow, true
+	return nil
 }
 
-func (r *resolvedTenantReplicationOptions) DestinationOptionsSet() bool {
-	return r != nil && (r.retention != nil || r.resumeTimestamp.IsSet())
+// typeCheckAutoCutover type checks the AUTO_CUTOVER option, if set, which
+// accepts the same expressions as COMPLETE REPLICATION TO SYSTEM TIME.
+func typeCheckAutoCutover(
+	ctx context.Context, semaCtx *tree.SemaContext, options tree.TenantReplicationOptions, op string,
+) error {
+	if options.AutoCutover == nil {
+		return nil
+	}
+	_, err := asof.TypeCheckSystemTimeExpr(ctx, semaCtx, options.AutoCutover, op)
+	return err
 }
 
-func alterReplicationJobTypeCheck(
-	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
-) (matched bool, header colinfo.ResultColumns, _ error) {
-	alterStmt, ok := stmt.(*tree.AlterTenantReplication)
-	if !ok {
-		return false, nil, nil
-	}
-	if err := exprutil.TypeCheck(
-		ctx, alterReplicationJobOp, p.SemaCtx(),
-		exprutil.TenantSpec{TenantSpec: alterStmt.TenantSpec},
-		exprutil.TenantSpec{TenantSpec: alterStmt.ReplicationSourceTenantName},
-		exprutil.Strings{alterStmt.Options.Retention, alterStmt.ReplicationSourceAddress},
-	); err != nil {
-		return false, nil, err
+// validateAutoCutover checks that the AUTO_CUTOVER time lies in the future of
+// the statement setting it. A time in the past would be cut over to as soon as
+// the job next records progress, which is what COMPLETE REPLICATION is for.
+func validateAutoCutover(ts hlc.Timestamp, stmtTimestamp time.Time) error {
+	if !stmtTimestamp.Before(ts.GoTime()) {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"invalid AUTO_CUTOVER %s: must be in the future", ts.GoTime())
 	}
+	return nil
+}
 
-	if cutoverTime := alterStmt.Cutover; cutoverTime != nil {
-		if cutoverTime.Timestamp != nil {
-			if _, err := asof.TypeCheckSystemTimeExpr(ctx, p.SemaCtx(),
-				cutoverTime.Timestamp, alterReplicationJobOp); err != nil {
-				return false, nil, err
-			}
-		}
-		return true, alterReplicationCutoverHeader, nil
+// maxCheckpointInterval bounds the CHECKPOINT_INTERVAL option, as the progress
+// made since the last checkpoint is redone whenever the job restarts.
+const maxCheckpointInterval = 10 * time.Minute
+
+// maxSourceConnPool bounds the SOURCE_CONN_POOL option, so that a single
+// replication job cannot exhaust the connections the source accepts.
+const maxSourceConnPool = 64
+
+// validateCheckpointInterval checks that the CHECKPOINT_INTERVAL option is a
+// positive duration of at most maxCheckpointInterval.
+func validateCheckpointInterval(interval time.Duration) error {
+	if interval <= 0 || interval > maxCheckpointInterval {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"invalid CHECKPOINT_INTERVAL %s: must be a positive duration of at most %s",
+			interval, maxCheckpointInterval)
 	}
+	return nil
+}
 
-	return true, nil, nil
+// validateInitialRetention checks that the initial retention of a replication
+// job, if set, is at least as long as its steady state retention, since it is
+// meant to retain more history while the job catches up, not less.
+func validateInitialRetention(initialTTLSeconds, ttlSeconds int32) error {
+	if initialTTLSeconds == 0 || initialTTLSeconds >= ttlSeconds {
+		return nil
+	}
+	return pgerror.Newf(pgcode.InvalidParameterValue,
+		"INITIAL_RETENTION (%s) must not be shorter than RETENTION (%s)",
+		time.Duration(initialTTLSeconds)*time.Second, time.Duration(ttlSeconds)*time.Second)
 }
 
-func alterReplicationJobHook(
-	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
-) (sql.PlanHookRowFn, colinfo.ResultColumns, []sql.PlanNode, bool, error) {
-	alterTenantStmt, ok := stmt.(*tree.AlterTenantReplication)
-	if !ok {
-		return nil, nil, nil, false, nil
+// parseResumePartitions parses the RESUME_PARTITIONS option, a JSON object
+// mapping partition IDs to the decimal HLC timestamp from which each should
+// resume, e.g. '{"1": "1700000000000000000.0000000000"}'.
+func parseResumePartitions(encoded string) (map[string]hlc.Timestamp, error) {
+	var raw map[string]string
+	if err := json.Unmarshal([]byte(encoded), &raw); err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue,
+			"invalid RESUME_PARTITIONS: expected a JSON object of partition IDs to timestamps")
 	}
+	if len(raw) == 0 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"invalid RESUME_PARTITIONS: at least one partition must be specified")
+	}
+	partitions := make(map[string]hlc.Timestamp, len(raw))
+	for id, tsStr := range raw {
+		if id == "" {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "invalid RESUME_PARTITIONS: partition ID cannot be empty")
+		}
+		ts, err := hlc.ParseHLC(tsStr)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid RESUME_PARTITIONS timestamp %q for partition %q", tsStr, id)
+		}
+		if ts.IsEmpty() {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid RESUME_PARTITIONS timestamp %q for partition %q: must be non-zero", tsStr, id)
+		}
+		partitions[id] = ts
+	}
+	return partitions, nil
+}
 
-	if !p.ExecCfg().Codec.ForSystemTenant() {
-		return nil, nil, nil, false, pgerror.Newf(pgcode.InsufficientPrivilege,
-			"only the system tenant can alter tenant")
+// validateResumePartitions checks that every resume timestamp lies within the
+// retention window of the replication job, as data older than that may have
+// been garbage collected on the source.
+func validateResumePartitions(
+	partitions map[string]hlc.Timestamp, now hlc.Timestamp, retentionTTLSeconds int32,
+) error {
+	earliest := now.AddDuration(-time.Duration(retentionTTLSeconds) * time.Second)
+	ids := make([]string, 0, len(partitions))
+	for id := range partitions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		ts := partitions[id]
+		if ts.Less(earliest) {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"RESUME_PARTITIONS timestamp %s for partition %q is older than the retention window (%s)",
+				ts, id, earliest)
+		}
+		if now.Less(ts) {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"RESUME_PARTITIONS timestamp %s for partition %q is in the future", ts, id)
+		}
 	}
+	return nil
+}
 
-	evalCtx := &p.ExtendedEvalContext().Context
-	var cutoverTime hlc.Timestamp
-	if alterTenantStmt.Cutover != nil {
-		if !alterTenantStmt.Cutover.Latest {
-			if alterTenantStmt.Cutover.Timestamp == nil {
-				return nil, nil, nil, false, errors.AssertionFailedf("unexpected nil cutover expression")
-			}
+// parseTargetNodes parses the TARGET_NODES option, a comma-separated list of
+// node IDs, returning the IDs in ascending order.
+func parseTargetNodes(nodes string) ([]roachpb.NodeID, error) {
+	var nodeIDs []roachpb.NodeID
+	for _, part := range strings.Split(nodes, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || id <= 0 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid TARGET_NODES %q: %q is not a valid node ID", nodes, part)
+		}
+		nodeID := roachpb.NodeID(id)
+		if slices.Contains(nodeIDs, nodeID) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid TARGET_NODES %q: node %d is listed more than once", nodes, id)
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	slices.Sort(nodeIDs)
+	return nodeIDs, nil
+}
 
-			ct, err := asof.EvalSystemTimeExpr(ctx, evalCtx, p.SemaCtx(), alterTenantStmt.Cutover.Timestamp,
-				alterReplicationJobOp, asof.ReplicationCutover)
-			if err != nil {
-				return nil, nil, nil, false, err
-			}
-			cutoverTime = ct
+// validateTargetNodes checks that every node listed in the TARGET_NODES option
+// is a node of the destination cluster, as known to getNodeDescriptor.
+func validateTargetNodes(
+	nodeIDs []roachpb.NodeID, getNodeDescriptor func(roachpb.NodeID) (*roachpb.NodeDescriptor, error),
+) error {
+	for _, nodeID := range nodeIDs {
+		if _, err := getNodeDescriptor(nodeID); err != nil {
+			return pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid TARGET_NODES: unknown node %d", nodeID)
 		}
 	}
+	return nil
+}
 
-	exprEval := p.ExprEvaluator(alterReplicationJobOp)
-	options, err := evalTenantReplicationOptions(ctx, alterTenantStmt.Options, exprEval, evalCtx, p.SemaCtx(), alterReplicationJobOp)
+// validateSeedFromBackupURI checks that the SEED_FROM_BACKUP option names a
+// well-formed backup location.
+func validateSeedFromBackupURI(uri string) error {
+	parsed, err := url.Parse(uri)
 	if err != nil {
-		return nil, nil, nil, false, err
+		return pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid SEED_FROM_BACKUP URI")
 	}
-
-	var srcAddr, srcTenant string
-	if alterTenantStmt.ReplicationSourceAddress != nil {
+	if parsed.Scheme == "" {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "invalid SEED_FROM_BACKUP URI %q: missing scheme", uri)
+	}
+	if parsed.Host == "" && parsed.Path == "" {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "invalid SEED_FROM_BACKUP URI %q: missing location", uri)
+	}
+	return nil
+}
+
+// validateEventSinkURI checks that the EVENT_SINK option names a sink of a
+// supported kind.
+func validateEventSinkURI(uri string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid EVENT_SINK URI")
+	}
+	if !slices.Contains(eventSinkSchemes, parsed.Scheme) {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"invalid EVENT_SINK URI %q: unsupported scheme %q, must be one of %s",
+			uri, parsed.Scheme, strings.Join(eventSinkSchemes, ", "))
+	}
+	if parsed.Host == "" {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "invalid EVENT_SINK URI %q: missing host", uri)
+	}
+	return nil
+}
+
+// validateEncryptionKey checks that the ENCRYPTION_KEY option is a well-formed
+// reference to a managed key. The reference may embed credentials, so it is
+// never included in the returned errors.
+func validateEncryptionKey(key string) error {
+	parsed, err := url.Parse(key)
+	if err != nil {
+		return pgerror.New(pgcode.InvalidParameterValue, "invalid ENCRYPTION_KEY: malformed key reference")
+	}
+	if !slices.Contains(encryptionKeySchemes, parsed.Scheme) {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"invalid ENCRYPTION_KEY: unsupported scheme %q, must be one of %s",
+			parsed.Scheme, strings.Join(encryptionKeySchemes, ", "))
+	}
+	if parsed.Host == "" && strings.Trim(parsed.Path, "/") == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "invalid ENCRYPTION_KEY: missing key identifier")
+	}
+	return nil
+}
+
+func (r *ResolvedTenantReplicationOptions) GetRetention() (int32, bool) {
+	if r == nil || r.retention == nil {
+		return 0, false
+	}
+	return *r.retention, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetExpirationWindow() (time.Duration, bool) {
+	if r == nil || r.expirationWindow == nil {
+		return 0, false
+	}
+	return *r.expirationWindow, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetSeedFromBackup() (string, bool) {
+	if r == nil || r.seedFromBackup == nil {
+		return "", false
+	}
+	return *r.seedFromBackup, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetPriority() (string, bool) {
+	if r == nil || r.priority == nil {
+		return "", false
+	}
+	return *r.priority, true
+}
+
+// GetResumePartitions returns the per-partition resume timestamps specified
+// via the RESUME_PARTITIONS option, keyed by partition ID.
+func (r *ResolvedTenantReplicationOptions) GetResumePartitions() (map[string]hlc.Timestamp, bool) {
+	if r == nil || r.resumePartitions == nil {
+		return nil, false
+	}
+	return r.resumePartitions, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetOwner() (string, bool) {
+	if r == nil || r.owner == nil {
+		return "", false
+	}
+	return *r.owner, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetServiceModeOnComplete() (mtinfopb.TenantServiceMode, bool) {
+	if r == nil || r.serviceMode == nil {
+		return mtinfopb.ServiceModeNone, false
+	}
+	return *r.serviceMode, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetPauseOnDiskFull() (int32, bool) {
+	if r == nil || r.pauseOnDiskFull == nil {
+		return 0, false
+	}
+	return *r.pauseOnDiskFull, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetMaxPartitionStreams() (int32, bool) {
+	if r == nil || r.maxPartitionStreams == nil {
+		return 0, false
+	}
+	return *r.maxPartitionStreams, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetPTSAdvanceInterval() (time.Duration, bool) {
+	if r == nil || r.ptsAdvanceInterval == nil {
+		return 0, false
+	}
+	return *r.ptsAdvanceInterval, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetVerifyChecksums() (bool, bool) {
+	if r == nil || r.verifyChecksums == nil {
+		return false, false
+	}
+	return *r.verifyChecksums, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetInitialRetention() (int32, bool) {
+	if r == nil || r.initialRetention == nil {
+		return 0, false
+	}
+	return *r.initialRetention, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetEventSink() (string, bool) {
+	if r == nil || r.eventSink == nil {
+		return "", false
+	}
+	return *r.eventSink, true
+}
+
+func (r *ResolvedTenantReplicationOptions) GetTargetNodes() ([]roachpb.NodeID, bool) {
+	if r == nil || r.targetNodes == nil {
+		return nil, false
+	}
+	return r.targetNodes, true
+}
+
+// GetAutoCutover returns the time to which the replication job cuts over on
+// its own once its replicated time reaches it, if it was specified. An empty
+// time is returned if AUTO_CUTOVER was set to NULL, to clear a scheduled
+// cutover.
+func (r *ResolvedTenantReplicationOptions) GetAutoCutover() (hlc.Timestamp, bool) {
+	if r == nil || r.autoCutover == nil {
+		return hlc.Timestamp{}, false
+	}
+	return *r.autoCutover, true
+}
+
+// GetIdleTimeout returns how long the replication job's replicated time may go
+// without advancing before the job pauses itself, if it was specified.
+func (r *ResolvedTenantReplicationOptions) GetIdleTimeout() (time.Duration, bool) {
+	if r == nil || r.idleTimeout == nil {
+		return 0, false
+	}
+	return *r.idleTimeout, true
+}
+
+// GetSpanConfigMode returns whether the destination virtual cluster inherits
+// the span configurations of the source or keeps its local ones, if it was
+// specified.
+func (r *ResolvedTenantReplicationOptions) GetSpanConfigMode() (string, bool) {
+	if r == nil || r.spanConfigMode == nil {
+		return "", false
+	}
+	return *r.spanConfigMode, true
+}
+
+// GetEncryptionKey returns the reference to the managed key with which the
+// replication stream is encrypted, if it was specified. The reference may embed
+// credentials and must not be logged.
+func (r *ResolvedTenantReplicationOptions) GetEncryptionKey() (string, bool) {
+	if r == nil || r.encryptionKey == nil {
+		return "", false
+	}
+	return *r.encryptionKey, true
+}
+
+// GetCheckpointInterval returns how often the replication job persists the
+// checkpoint of its ingestion frontier, if it was specified.
+func (r *ResolvedTenantReplicationOptions) GetCheckpointInterval() (time.Duration, bool) {
+	if r == nil || r.checkpointInterval == nil {
+		return 0, false
+	}
+	return *r.checkpointInterval, true
+}
+
+// GetSourceConnPool returns the number of connections to the source the
+// consumer pools, if it was specified.
+func (r *ResolvedTenantReplicationOptions) GetSourceConnPool() (int32, bool) {
+	if r == nil || r.sourceConnPool == nil {
+		return 0, false
+	}
+	return *r.sourceConnPool, true
+}
+
+// GetIngestMode returns how the replication job ingests the KVs it receives,
+// one of "raw" or "row", if it was specified.
+func (r *ResolvedTenantReplicationOptions) GetIngestMode() (string, bool) {
+	if r == nil || r.ingestMode == nil {
+		return "", false
+	}
+	return *r.ingestMode, true
+}
+
+// GetOnError returns what the replication job does when ingestion hits an
+// error it cannot retry, one of "pause" or "fail", if it was specified.
+func (r *ResolvedTenantReplicationOptions) GetOnError() (string, bool) {
+	if r == nil || r.onError == nil {
+		return "", false
+	}
+	return *r.onError, true
+}
+
+func (r *ResolvedTenantReplicationOptions) DestinationOptionsSet() bool {
+	return r != nil && (r.retention != nil || r.initialRetention != nil || r.priority != nil || r.resumePartitions != nil ||
+		r.owner != nil || r.serviceMode != nil || r.pauseOnDiskFull != nil ||
+		r.maxPartitionStreams != nil || r.ptsAdvanceInterval != nil || r.verifyChecksums != nil ||
+		r.eventSink != nil || r.targetNodes != nil || r.onError != nil || r.autoCutover != nil ||
+		r.idleTimeout != nil || r.spanConfigMode != nil || r.encryptionKey != nil ||
+		r.checkpointInterval != nil || r.sourceConnPool != nil || r.ingestMode != nil ||
+		r.resumeTimestamp.IsSet())
+}
+
+// validateCutoverTarget checks that a COMPLETE REPLICATION statement does not
+// name both LATEST and an explicit cutover time. The grammar cannot produce
+// such a statement, but one constructed otherwise would silently cut over to
+// the latest time.
+func validateCutoverTarget(cutover *tree.ReplicationCutoverTime) error {
+	if cutover.Latest && cutover.Timestamp != nil {
+		return pgerror.New(pgcode.Syntax,
+			"cannot specify both LATEST and an explicit cutover timestamp")
+	}
+	return nil
+}
+
+func alterReplicationJobTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	alterStmt, ok := stmt.(*tree.AlterTenantReplication)
+	if !ok || alterStmt.TenantSpec.All {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, alterReplicationJobOp, p.SemaCtx(),
+		exprutil.TenantSpec{TenantSpec: alterStmt.TenantSpec},
+		exprutil.TenantSpec{TenantSpec: alterStmt.ReplicationSourceTenantName},
+		exprutil.TenantSpec{TenantSpec: alterStmt.NewReplicationSourceTenantName},
+		exprutil.Strings{
+			alterStmt.Options.Retention,
+			alterStmt.Options.RetentionFromZone,
+			alterStmt.Options.InitialRetention,
+			alterStmt.Options.SeedFromBackup,
+			alterStmt.Options.Priority,
+			alterStmt.Options.ResumePartitions,
+			alterStmt.Options.Owner,
+			alterStmt.Options.ServiceModeOnComplete,
+			alterStmt.Options.EventSink,
+			alterStmt.Options.TargetNodes,
+			alterStmt.Options.OnError,
+			alterStmt.Options.IdleTimeout,
+			alterStmt.Options.SpanConfig,
+			alterStmt.Options.EncryptionKey,
+			alterStmt.Options.CheckpointInterval,
+			alterStmt.Options.IngestMode,
+			alterStmt.ReplicationSourceAddress,
+		},
+		exprutil.Ints{
+			alterStmt.Options.PauseOnDiskFull,
+			alterStmt.Options.MaxPartitionStreams,
+			alterStmt.Options.SourceConnPool,
+			alterStmt.AdoptReplicationJob,
+			alterStmt.NewReplicationTargetTenantID,
+		},
+		exprutil.Bools{alterStmt.Options.VerifyChecksums},
+	); err != nil {
+		return false, nil, err
+	}
+	if err := typeCheckAutoCutover(ctx, p.SemaCtx(), alterStmt.Options, alterReplicationJobOp); err != nil {
+		return false, nil, err
+	}
+
+	if cutoverTime := alterStmt.Cutover; cutoverTime != nil {
+		if err := validateCutoverTarget(cutoverTime); err != nil {
+			return false, nil, err
+		}
+		if cutoverTime.Timestamp != nil {
+			if _, err := asof.TypeCheckSystemTimeExpr(ctx, p.SemaCtx(),
+				cutoverTime.Timestamp, alterReplicationJobOp); err != nil {
+				return false, nil, err
+			}
+		}
+		if err := exprutil.TypeCheck(
+			ctx, alterReplicationJobOp, p.SemaCtx(),
+			exprutil.Strings{cutoverTime.Event, cutoverTime.Options.ClockSkewTolerance},
+		); err != nil {
+			return false, nil, err
+		}
+		return true, cutoverResultHeader(cutoverTime.Options), nil
+	}
+	if alterStmt.Options.ValidateOnly {
+		return true, alterReplicationValidateOnlyHeader, nil
+	}
+	if alterStmt.ShowLastRevert {
+		return true, alterReplicationLastRevertHeader, nil
+	}
+	if alterStmt.TestReplicationSource {
+		return true, alterReplicationTestSourceHeader, nil
+	}
+
+	return true, nil, nil
+}
+
+func alterReplicationJobHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, []sql.PlanNode, bool, error) {
+	alterTenantStmt, ok := stmt.(*tree.AlterTenantReplication)
+	if !ok || alterTenantStmt.TenantSpec.All {
+		return nil, nil, nil, false, nil
+	}
+	if err := validateReplicationSource(alterTenantStmt); err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	if !p.ExecCfg().Codec.ForSystemTenant() {
+		return nil, nil, nil, false, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only the system tenant can alter tenant")
+	}
+	// Check the license before evaluating any of the statement's options, so
+	// that a missing license is reported before any errors in those.
+	if err := utilccl.CheckEnterpriseEnabled(
+		p.ExecCfg().Settings,
+		alterReplicationJobOp,
+	); err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	evalCtx := &p.ExtendedEvalContext().Context
+	var cutoverTime hlc.Timestamp
+	if alterTenantStmt.Cutover != nil {
+		if !alterTenantStmt.Cutover.Latest && alterTenantStmt.Cutover.Event == nil {
+			if alterTenantStmt.Cutover.Timestamp == nil {
+				return nil, nil, nil, false, errors.AssertionFailedf("unexpected nil cutover expression")
+			}
+
+			ct, err := asof.EvalSystemTimeExpr(ctx, evalCtx, p.SemaCtx(), alterTenantStmt.Cutover.Timestamp,
+				alterReplicationJobOp, asof.ReplicationCutover)
+			if err != nil {
+				return nil, nil, nil, false, err
+			}
+			cutoverTime = ct
+		}
+	}
+
+	if alterTenantStmt.Options.ValidateOnly && alterTenantStmt.ReplicationSourceAddress == nil {
+		return nil, nil, nil, false, validateOnlyUnsupportedErr
+	}
+
+	exprEval := p.ExprEvaluator(alterReplicationJobOp)
+	options, err := evalTenantReplicationOptions(ctx, p, alterTenantStmt.Options, alterReplicationJobOp)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	var cutoverEvent string
+	if alterTenantStmt.Cutover != nil && alterTenantStmt.Cutover.Event != nil {
+		cutoverEvent, err = exprEval.String(ctx, alterTenantStmt.Cutover.Event)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+	var clockSkewTolerance time.Duration
+	if alterTenantStmt.Cutover != nil && alterTenantStmt.Cutover.Options.ClockSkewTolerance != nil {
+		clockSkewTolerance, err = evalClockSkewTolerance(
+			ctx, exprEval, alterTenantStmt.Cutover.Options.ClockSkewTolerance)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+
+	var srcAddr, srcTenant string
+	if alterTenantStmt.ReplicationSourceAddress != nil {
 		srcAddr, err = exprEval.String(ctx, alterTenantStmt.ReplicationSourceAddress)
 		if err != nil {
 			return nil, nil, nil, false, err
@@ -187,92 +1111,693 @@ func alterReplicationJobHook(
 			return nil, nil, nil, false, err
 		}
 	}
-
-	retentionTTLSeconds := defaultRetentionTTLSeconds
-	if ret, ok := options.GetRetention(); ok {
-		retentionTTLSeconds = ret
+
+	var newSrcTenant roachpb.TenantName
+	if alterTenantStmt.NewReplicationSourceTenantName != nil {
+		_, _, name, err := exprEval.TenantSpec(ctx, alterTenantStmt.NewReplicationSourceTenantName)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+		newSrcTenant = roachpb.TenantName(name)
+		if err := newSrcTenant.IsValid(); err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+
+	var newTargetTenantID roachpb.TenantID
+	if alterTenantStmt.NewReplicationTargetTenantID != nil {
+		id, err := exprEval.Int(ctx, alterTenantStmt.NewReplicationTargetTenantID)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+		if id <= 0 {
+			return nil, nil, nil, false, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid replication target tenant ID %d", id)
+		}
+		newTargetTenantID = roachpb.MustMakeTenantID(uint64(id))
+	}
+
+	var adoptJobID jobspb.JobID
+	if alterTenantStmt.AdoptReplicationJob != nil {
+		id, err := exprEval.Int(ctx, alterTenantStmt.AdoptReplicationJob)
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+		adoptJobID = jobspb.JobID(id)
+	}
+
+	retentionTTLSeconds := defaultRetentionTTLSeconds
+	if ret, ok := options.GetRetention(); ok {
+		retentionTTLSeconds = ret
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		if err := sql.CanManageTenant(ctx, p); err != nil {
+			return err
+		}
+
+		tenInfo, err := p.LookupTenantInfo(ctx, alterTenantStmt.TenantSpec, alterReplicationJobOp)
+		if err != nil {
+			return err
+		}
+		if alterTenantStmt.ShowLastRevert {
+			// Reading the record does not need to be serialized with the
+			// statements that update it, so this is answered without locking it.
+			resultsCh <- tree.Datums{lastRevertDatum(tenInfo)}
+			return nil
+		}
+		if alterTenantStmt.TestReplicationSource {
+			// The source is contacted without locking the record, so that a
+			// slow source does not hold up the statements that update it.
+			return alterTenantTestReplicationSource(ctx, p, p.ExecCfg().JobRegistry, tenInfo, resultsCh)
+		}
+		tenInfo, err = lockTenantRecord(ctx, p.InternalSQLTxn(), p.ExecCfg().Settings, tenInfo)
+		if err != nil {
+			return err
+		}
+
+		// If a source address is being provided, we're enabling replication into an
+		// existing virtual cluster. It must be inactive, and we'll verify that it
+		// was the cluster from which the one it will replicate was replicated, i.e.
+		// that we're reversing the direction of replication. We will then revert it
+		// to the time they diverged and pick up from there.
+		if alterTenantStmt.ReplicationSourceAddress != nil {
+			return alterTenantRestartReplication(
+				ctx,
+				p,
+				tenInfo,
+				srcAddr,
+				srcTenant,
+				retentionTTLSeconds,
+				options,
+				alterTenantStmt,
+				resultsCh,
+			)
+		}
+		jobRegistry := p.ExecCfg().JobRegistry
+		if !alterTenantStmt.Options.IsDefault() {
+			// If the statement contains options, then the user provided the ALTER
+			// TENANT ... SET REPLICATION [options] form of the command.
+			return alterTenantSetReplication(ctx, p.InternalSQLTxn(), jobRegistry, options, tenInfo)
+		}
+		if alterTenantStmt.AdoptReplicationJob != nil {
+			return alterTenantAdoptReplicationJob(ctx, p, jobRegistry, tenInfo, adoptJobID)
+		}
+		if alterTenantStmt.ClearReplicationJobReference {
+			return alterTenantClearReplicationJobReference(ctx, p, jobRegistry, tenInfo)
+		}
+		if err := checkForActiveIngestionJob(tenInfo); err != nil {
+			return err
+		}
+		if alterTenantStmt.RefreshStatus {
+			return alterTenantRefreshStatus(ctx, p, jobRegistry, tenInfo)
+		}
+		if alterTenantStmt.NewReplicationSourceTenantName != nil {
+			return alterTenantSourceTenantName(ctx, p, jobRegistry, tenInfo, newSrcTenant)
+		}
+		if alterTenantStmt.NewReplicationTargetTenantID != nil {
+			return alterTenantReplicationTarget(ctx, p, jobRegistry, tenInfo, newTargetTenantID)
+		}
+		if alterTenantStmt.Cutover != nil {
+			cutoverTime := cutoverTime
+			if alterTenantStmt.Cutover.Event != nil {
+				cutoverTime, err = resolveCutoverEvent(ctx, p.InternalSQLTxn(), &p.ExecCfg().Settings.SV, cutoverEvent)
+				if err != nil {
+					return err
+				}
+			}
+			actualCutoverTime, err := alterTenantJobCutover(
+				ctx, p.InternalSQLTxn(), jobRegistry, protectedTimestampStorage(p), p, &p.ExecCfg().Settings.SV, alterTenantStmt, tenInfo, cutoverTime, clockSkewTolerance)
+			if err != nil {
+				return err
+			}
+			if alterTenantStmt.Cutover.Options.Format == cutoverFormatJSON {
+				resultsCh <- tree.Datums{cutoverJSONDatum(actualCutoverTime, alterTenantStmt.Cutover.Latest, tenInfo)}
+			} else {
+				resultsCh <- tree.Datums{eval.TimestampToDecimalDatum(actualCutoverTime)}
+			}
+		} else {
+			if err := alterTenantJobState(
+				ctx, p, jobRegistry, alterTenantStmt.Command, alterTenantStmt.RefreshTopology, tenInfo,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if alterTenantStmt.Cutover != nil {
+		return fn, cutoverResultHeader(alterTenantStmt.Cutover.Options), nil, false, nil
+	}
+	if alterTenantStmt.Options.ValidateOnly {
+		return fn, alterReplicationValidateOnlyHeader, nil, false, nil
+	}
+	if alterTenantStmt.ShowLastRevert {
+		return fn, alterReplicationLastRevertHeader, nil, false, nil
+	}
+	if alterTenantStmt.TestReplicationSource {
+		return fn, alterReplicationTestSourceHeader, nil, false, nil
+	}
+	return fn, nil, nil, false, nil
+}
+
+// validateReplicationSource checks that the source address and source tenant
+// name of ALTER VIRTUAL CLUSTER ... START REPLICATION are either both present
+// or both absent, since the statement is otherwise interpreted as one of the
+// other ALTER VIRTUAL CLUSTER REPLICATION forms.
+func validateReplicationSource(stmt *tree.AlterTenantReplication) error {
+	hasAddress := stmt.ReplicationSourceAddress != nil
+	hasTenant := stmt.ReplicationSourceTenantName != nil
+	if hasAddress && !hasTenant {
+		return pgerror.New(pgcode.Syntax,
+			"a replication source address requires the name of the source virtual cluster")
+	}
+	if hasTenant && !hasAddress {
+		return pgerror.New(pgcode.Syntax,
+			"a replication source virtual cluster requires the address of the source cluster")
+	}
+	return nil
+}
+
+// lastRevertDatum returns the time to which the tenant's data was last reverted
+// as a decimal, or NULL if it has not been reverted since replication into it
+// was last started.
+func lastRevertDatum(tenInfo *mtinfopb.TenantInfo) tree.Datum {
+	if tenInfo.LastRevertTenantTimestamp.IsEmpty() {
+		return tree.DNull
+	}
+	return eval.TimestampToDecimalDatum(tenInfo.LastRevertTenantTimestamp)
+}
+
+// lockTenantRecord locks the tenant's record in system.tenants until the end of
+// the transaction, so that ALTER VIRTUAL CLUSTER REPLICATION statements issued
+// concurrently against the same tenant, e.g. a restart racing a cutover, are
+// serialized rather than interleaving their updates to the record. The
+// tenant's info is re-read under the lock, since it may have been changed by
+// the statement that held it before.
+func lockTenantRecord(
+	ctx context.Context, txn isql.Txn, settings *cluster.Settings, tenInfo *mtinfopb.TenantInfo,
+) (*mtinfopb.TenantInfo, error) {
+	row, err := txn.QueryRowEx(ctx, "lock-tenant", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT id FROM system.tenants WHERE id = $1 FOR UPDATE`, tenInfo.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "locking tenant %q", tenInfo.Name)
+	} else if row == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "tenant %q does not exist", tenInfo.Name)
+	}
+	return sql.GetTenantRecordByID(ctx, txn, roachpb.MustMakeTenantID(tenInfo.ID), settings)
+}
+
+// alterTenantJobState pauses or resumes the tenant's replication consumer job.
+// Pausing a job that is already paused, or resuming one that is already
+// running, is a no-op that only emits a notice, so that these commands can be
+// safely retried.
+func alterTenantJobState(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobRegistry *jobs.Registry,
+	command tree.JobCommand,
+	refreshTopology bool,
+	tenInfo *mtinfopb.TenantInfo,
+) error {
+	skipped, status, err := setTenantJobState(
+		ctx, p.InternalSQLTxn(), jobRegistry, protectedTimestampStorage(p), command, refreshTopology, tenInfo)
+	if err != nil {
+		return err
+	}
+	if skipped {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"replication job %d for tenant %q is already %s",
+			tenInfo.PhysicalReplicationConsumerJobID, tenInfo.Name, status))
+	}
+	return nil
+}
+
+// protectedTimestampStorage returns the protected timestamp storage bound to
+// the planner's transaction, or nil if the cluster has no provider.
+func protectedTimestampStorage(p sql.PlanHookState) protectedts.Storage {
+	ptp := p.ExecCfg().ProtectedTimestampProvider
+	if ptp == nil {
+		return nil
+	}
+	return ptp.WithTxn(p.InternalSQLTxn())
+}
+
+// setTenantJobState pauses or resumes the tenant's replication consumer job. If
+// the job is already in the requested state it is left untouched, and skipped
+// is returned as true along with the job's current status. Before a job is
+// resumed, the point it will resume from is validated against its protected
+// timestamp record, if pts is non-nil. If refreshTopology is set, the resumed
+// job re-queries the partition layout of the source instead of reusing the one
+// it last planned with.
+func setTenantJobState(
+	ctx context.Context,
+	txn isql.Txn,
+	jobRegistry *jobs.Registry,
+	pts protectedts.Storage,
+	command tree.JobCommand,
+	refreshTopology bool,
+	tenInfo *mtinfopb.TenantInfo,
+) (skipped bool, _ jobs.Status, _ error) {
+	jobID := tenInfo.PhysicalReplicationConsumerJobID
+	job, err := jobRegistry.LoadJobWithTxn(ctx, jobID, txn)
+	if err != nil {
+		return false, "", err
+	}
+	status := job.Status()
+	switch command {
+	case tree.ResumeJob:
+		if status == jobs.StatusRunning || status == jobs.StatusReverting {
+			return true, status, nil
+		}
+		if pts != nil {
+			details, ok := job.Details().(jobspb.StreamIngestionDetails)
+			if !ok {
+				return false, "", errors.Newf("job with id %d is not a stream ingestion job", jobID)
+			}
+			progress := job.Progress()
+			if err := validateResumePoint(ctx, pts, jobID, details, &progress); err != nil {
+				return false, "", err
+			}
+		}
+		if refreshTopology {
+			if err := job.WithTxn(txn).Update(ctx, func(
+				txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+			) error {
+				md.Progress.GetStreamIngest().RefreshTopology = true
+				ju.UpdateProgress(md.Progress)
+				return nil
+			}); err != nil {
+				return false, "", err
+			}
+		}
+		return false, status, jobRegistry.Unpause(ctx, txn, jobID)
+	case tree.PauseJob:
+		if status == jobs.StatusPaused || status == jobs.StatusPauseRequested {
+			return true, status, nil
+		}
+		return false, status, jobRegistry.PauseRequested(ctx, txn, jobID,
+			"ALTER VIRTUAL CLUSTER PAUSE REPLICATION")
+	default:
+		return false, "", errors.New("unsupported job command in ALTER VIRTUAL CLUSTER REPLICATION")
+	}
+}
+
+// validateResumePoint checks that the replication job can resume from its last
+// checkpoint, i.e. that its replicated time, or its start time if it has not
+// replicated anything yet, is still covered by the job's protected timestamp
+// record. If the record was released or has advanced past the resume point, the
+// data the job would resume from may have been garbage collected.
+func validateResumePoint(
+	ctx context.Context,
+	pts protectedts.Storage,
+	jobID jobspb.JobID,
+	details jobspb.StreamIngestionDetails,
+	progress *jobspb.Progress,
+) error {
+	if details.ProtectedTimestampRecordID == nil {
+		return nil
+	}
+	resumeTime := replicationutils.ReplicatedTimeFromProgress(progress)
+	if resumeTime.IsEmpty() {
+		resumeTime = details.ReplicationStartTime
+	}
+	record, err := pts.GetRecord(ctx, *details.ProtectedTimestampRecordID)
+	if err != nil {
+		if errors.Is(err, protectedts.ErrNotExists) {
+			return errors.WithHint(
+				errors.Newf("cannot resume replication job %d: its protected timestamp record %s no longer exists",
+					jobID, *details.ProtectedTimestampRecordID),
+				"the data to resume from may have been garbage collected; "+
+					"start a new replication stream instead")
+		}
+		return err
+	}
+	if resumeTime.Less(record.Timestamp) {
+		return errors.WithHint(
+			errors.Newf("cannot resume replication job %d: its resume point %s is below the earliest retained timestamp %s",
+				jobID, resumeTime, record.Timestamp),
+			"the data to resume from may have been garbage collected; "+
+				"start a new replication stream instead")
+	}
+	return nil
+}
+
+// alterTenantRefreshStatus asks the tenant's running replication consumer job
+// to persist its replicated time and checkpoint immediately, by setting a
+// sentinel in the job progress that the job polls for. It is a no-op that only
+// emits a notice if the job is not running.
+func alterTenantRefreshStatus(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobRegistry *jobs.Registry,
+	tenInfo *mtinfopb.TenantInfo,
+) error {
+	jobID := tenInfo.PhysicalReplicationConsumerJobID
+	return jobRegistry.UpdateJobWithTxn(ctx, jobID, p.InternalSQLTxn(),
+		func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+			if md.Status != jobs.StatusRunning {
+				p.BufferClientNotice(ctx, pgnotice.Newf(
+					"replication job %d for tenant %q is %s, not refreshing its status",
+					jobID, tenInfo.Name, md.Status))
+				return nil
+			}
+			md.Progress.GetStreamIngest().RefreshRequested = true
+			ju.UpdateProgress(md.Progress)
+			return nil
+		})
+}
+
+// alterTenantTestReplicationSource connects to the source of the tenant's
+// replication job, checks that the source tenant is still the one the job was
+// started from, and reports the outcome as a row of
+// alterReplicationTestSourceHeader. Failing to reach the source is reported in
+// the row rather than returned as an error.
+func alterTenantTestReplicationSource(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobRegistry *jobs.Registry,
+	tenInfo *mtinfopb.TenantInfo,
+	resultsCh chan<- tree.Datums,
+) error {
+	if err := checkForActiveIngestionJob(tenInfo); err != nil {
+		return err
+	}
+	job, err := jobRegistry.LoadJobWithTxn(ctx, tenInfo.PhysicalReplicationConsumerJobID, p.InternalSQLTxn())
+	if err != nil {
+		return err
+	}
+	details, ok := job.Details().(jobspb.StreamIngestionDetails)
+	if !ok {
+		return errors.Newf("job with id %d is not a stream ingestion job", job.ID())
+	}
+	newClient := func(ctx context.Context) (streamclient.Client, error) {
+		return streamclient.NewStreamClient(ctx, crosscluster.StreamAddress(details.StreamAddress), p.ExecCfg().InternalDB)
+	}
+	resultsCh <- testReplicationSource(ctx, newClient, details,
+		crosscluster.SourceOperationTimeout.Get(&p.ExecCfg().Settings.SV))
+	return nil
+}
+
+// testReplicationSource connects to the source of the replication job with the
+// given details using newClient, and returns a row of
+// alterReplicationTestSourceHeader describing whether the source tenant could
+// be reached and still has the history ID the job was started from. Each
+// interaction with the source is bounded by the given timeout.
+func testReplicationSource(
+	ctx context.Context,
+	newClient func(context.Context) (streamclient.Client, error),
+	details jobspb.StreamIngestionDetails,
+	timeout time.Duration,
+) tree.Datums {
+	var client streamclient.Client
+	var srcID string
+	err := timeutil.RunWithTimeout(ctx, "creating stream client", timeout,
+		func(ctx context.Context) (err error) {
+			client, err = newClient(ctx)
+			return err
+		})
+	if err == nil {
+		err = timeutil.RunWithTimeout(ctx, "fetching prior replication details", timeout,
+			func(ctx context.Context) (err error) {
+				srcID, _, _, err = client.PriorReplicationDetails(ctx, details.SourceTenantName)
+				return err
+			})
+		err = errors.CombineErrors(err,
+			timeutil.RunWithTimeout(ctx, "closing stream client", timeout, client.Close))
+	}
+	if err != nil {
+		return tree.Datums{
+			tree.NewDString(replicationSourceUnreachable), tree.DNull, tree.NewDString(err.Error()),
+		}
+	}
+
+	expectedID := fmt.Sprintf("%s:%s", details.SourceClusterID, details.SourceTenantID)
+	if srcID != expectedID {
+		return tree.Datums{
+			tree.NewDString(replicationSourceMismatched),
+			tree.NewDString(srcID),
+			tree.NewDString(fmt.Sprintf("source virtual cluster %q has history ID %s, but the job replicates %s",
+				details.SourceTenantName, srcID, expectedID)),
+		}
+	}
+	return tree.Datums{tree.NewDString(replicationSourceReachable), tree.NewDString(srcID), tree.DNull}
+}
+
+// alterTenantSourceTenantName updates the name of the source tenant stored in
+// the tenant's replication job, e.g. after the tenant was renamed on the
+// source, so that the job uses the new name when it next connects to the
+// source. The new name must exist on the source.
+func alterTenantSourceTenantName(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobRegistry *jobs.Registry,
+	tenInfo *mtinfopb.TenantInfo,
+	srcTenant roachpb.TenantName,
+) error {
+	jobID := tenInfo.PhysicalReplicationConsumerJobID
+	job, err := jobRegistry.LoadJobWithTxn(ctx, jobID, p.InternalSQLTxn())
+	if err != nil {
+		return err
+	}
+	details, ok := job.Details().(jobspb.StreamIngestionDetails)
+	if !ok {
+		return errors.Newf("job with id %d is not a stream ingestion job", jobID)
+	}
+	if details.SourceTenantName == srcTenant {
+		return nil
+	}
+
+	// The tenant record is locked while the source is validated, so each
+	// interaction with the source is bounded to not block other statements on
+	// the tenant indefinitely.
+	timeout := crosscluster.SourceOperationTimeout.Get(&p.ExecCfg().Settings.SV)
+	var client streamclient.Client
+	if err := timeutil.RunWithTimeout(ctx, "creating stream client", timeout,
+		func(ctx context.Context) (err error) {
+			client, err = streamclient.NewStreamClient(ctx, crosscluster.StreamAddress(details.StreamAddress), p.ExecCfg().InternalDB)
+			return err
+		}); err != nil {
+		return errors.Wrap(err, "creating client")
+	}
+	closeClient := func() error {
+		return timeutil.RunWithTimeout(ctx, "closing stream client", timeout, client.Close)
+	}
+	if err := timeutil.RunWithTimeout(ctx, "validating source tenant", timeout,
+		func(ctx context.Context) error {
+			_, _, _, err := client.PriorReplicationDetails(ctx, srcTenant)
+			return err
+		}); err != nil {
+		return errors.CombineErrors(
+			errors.Wrapf(err, "validating source tenant %q", srcTenant), closeClient())
+	}
+	if err := closeClient(); err != nil {
+		return err
+	}
+
+	return jobRegistry.UpdateJobWithTxn(ctx, jobID, p.InternalSQLTxn(),
+		func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+			md.Payload.GetStreamIngestion().SourceTenantName = srcTenant
+			ju.UpdatePayload(md.Payload)
+			return nil
+		})
+}
+
+// alterTenantReplicationTarget re-targets the tenant's replication job at the
+// tenant with the given ID, as a way to recover from the destination tenant
+// having been renumbered. It is the converse of ADOPT REPLICATION JOB, which it
+// defers to once the job is confirmed not to be cutting over: the job must be
+// paused and ingest into the keyspace of its current tenant, and the new target
+// must be an existing, offline replication target with no job of its own.
+func alterTenantReplicationTarget(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobRegistry *jobs.Registry,
+	tenInfo *mtinfopb.TenantInfo,
+	targetID roachpb.TenantID,
+) error {
+	if targetID.ToUint64() == tenInfo.ID {
+		return nil
+	}
+	jobID := tenInfo.PhysicalReplicationConsumerJobID
+	txn := p.InternalSQLTxn()
+	job, err := jobRegistry.LoadJobWithTxn(ctx, jobID, txn)
+	if err != nil {
+		return err
+	}
+	progress := job.Progress().GetStreamIngest()
+	if progress == nil {
+		return errors.Newf("job with id %d is not a stream ingestion job", jobID)
+	}
+	if status := progress.ReplicationStatus; status == jobspb.ReplicationPendingCutover ||
+		status == jobspb.ReplicationCuttingOver || !progress.CutoverTime.IsEmpty() {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot re-target replication job %d of virtual cluster %q: it is cutting over (status: %s)",
+			jobID, tenInfo.Name, status)
+	}
+	if status := job.Status(); status != jobs.StatusPaused {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"replication job %d must be paused to be re-targeted, not %s", jobID, status)
+	}
+
+	targetInfo, err := sql.GetTenantRecordByID(ctx, txn, targetID, p.ExecCfg().Settings)
+	if err != nil {
+		return err
+	}
+	targetInfo, err = lockTenantRecord(ctx, txn, p.ExecCfg().Settings, targetInfo)
+	if err != nil {
+		return err
+	}
+	return alterTenantAdoptReplicationJob(ctx, p, jobRegistry, targetInfo, jobID)
+}
+
+// alterTenantClearReplicationJobReference removes the tenant's reference to its
+// replication consumer job, as a way to recover a tenant whose job was dropped
+// without the tenant record being updated, which leaves every other ALTER
+// VIRTUAL CLUSTER REPLICATION statement failing to load the job. The reference
+// is only removed once the job is confirmed to no longer exist, since the
+// tenant of a job that does exist must not be re-targeted underneath it.
+func alterTenantClearReplicationJobReference(
+	ctx context.Context, p sql.PlanHookState, jobRegistry *jobs.Registry, tenInfo *mtinfopb.TenantInfo,
+) error {
+	jobID := tenInfo.PhysicalReplicationConsumerJobID
+	if jobID == 0 {
+		return nil
+	}
+	txn := p.InternalSQLTxn()
+	if _, err := jobRegistry.LoadJobWithTxn(ctx, jobID, txn); err == nil {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.ObjectInUse,
+				"cannot clear reference of tenant %q (%d) to replication job %d: the job still exists",
+				tenInfo.Name, tenInfo.ID, jobID),
+			"cancel the replication job instead")
+	} else if !jobs.HasJobNotFoundError(err) {
+		return err
+	}
+	tenInfo.PhysicalReplicationConsumerJobID = 0
+	return sql.UpdateTenantRecord(ctx, p.ExecCfg().Settings, txn, tenInfo)
+}
+
+// alterTenantAdoptReplicationJob transfers the given replication consumer job
+// to the tenant, e.g. when re-homing a replication stream: the job is
+// re-pointed at the tenant's keyspace, its protected timestamp record is moved
+// over to the tenant, and the tenant the job replicated into until now, if it
+// still references the job, is detached from it. All of this happens in the
+// plan hook's transaction.
+//
+// The job must be paused, and the tenant must be an inactive replication
+// target, i.e. in the ADD data state with no replication job of its own.
+func alterTenantAdoptReplicationJob(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jobRegistry *jobs.Registry,
+	tenInfo *mtinfopb.TenantInfo,
+	jobID jobspb.JobID,
+) error {
+	if tenInfo.PhysicalReplicationConsumerJobID == jobID {
+		return nil
+	}
+	if tenInfo.PhysicalReplicationConsumerJobID != 0 {
+		return errors.Newf("tenant %q (%d) already has replication consumer job %d",
+			tenInfo.Name, tenInfo.ID, tenInfo.PhysicalReplicationConsumerJobID)
+	}
+	if tenInfo.DataState != mtinfopb.DataStateAdd {
+		return errors.Newf("tenant %q (%d) must be in data state %s to adopt a replication job, not %s",
+			tenInfo.Name, tenInfo.ID, mtinfopb.DataStateAdd, tenInfo.DataState)
+	}
+	if tenInfo.ServiceMode != mtinfopb.ServiceModeNone {
+		return errors.Newf("tenant %q (%d) must have service mode %s to adopt a replication job, not %s",
+			tenInfo.Name, tenInfo.ID, mtinfopb.ServiceModeNone, tenInfo.ServiceMode)
+	}
+	dstTenantID, err := roachpb.MakeTenantID(tenInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	txn := p.InternalSQLTxn()
+	job, err := jobRegistry.LoadJobWithTxn(ctx, jobID, txn)
+	if err != nil {
+		return err
+	}
+	details, ok := job.Details().(jobspb.StreamIngestionDetails)
+	if !ok {
+		return errors.Newf("job with id %d is not a stream ingestion job", jobID)
+	}
+	if status := job.Status(); status != jobs.StatusPaused {
+		return errors.Newf("replication job %d must be paused to be adopted, not %s", jobID, status)
+	}
+	prevTenantID := details.DestinationTenantID
+	if prevTenantID == dstTenantID {
+		return errors.AssertionFailedf("replication job %d already replicates into tenant %q (%d)",
+			jobID, tenInfo.Name, tenInfo.ID)
+	}
+	if prevSpan := keys.MakeTenantSpan(prevTenantID); !details.Span.Equal(prevSpan) {
+		return errors.Newf("replication job %d ingests into %s rather than the keyspace %s of its tenant %s",
+			jobID, details.Span, prevSpan, prevTenantID)
 	}
 
-	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
-		if err := utilccl.CheckEnterpriseEnabled(
-			p.ExecCfg().Settings,
-			alterReplicationJobOp,
-		); err != nil {
+	settings := p.ExecCfg().Settings
+	prevInfo, err := sql.GetTenantRecordByID(ctx, txn, prevTenantID, settings)
+	if err != nil && pgerror.GetPGCode(err) != pgcode.UndefinedObject {
+		return err
+	}
+	if err == nil && prevInfo.PhysicalReplicationConsumerJobID == jobID {
+		prevInfo.PhysicalReplicationConsumerJobID = 0
+		if err := sql.UpdateTenantRecord(ctx, settings, txn, prevInfo); err != nil {
 			return err
 		}
+	}
 
-		if err := sql.CanManageTenant(ctx, p); err != nil {
-			return err
+	// The protected timestamp record targets the keyspace of the tenant, so it
+	// is replaced by one protecting the same timestamp over the new tenant.
+	ptsID := details.ProtectedTimestampRecordID
+	if ptsID != nil {
+		ptp := protectedTimestampStorage(p)
+		if ptp == nil {
+			return errors.New("protected timestamp subsystem unavailable")
 		}
-
-		tenInfo, err := p.LookupTenantInfo(ctx, alterTenantStmt.TenantSpec, alterReplicationJobOp)
+		record, err := ptp.GetRecord(ctx, *ptsID)
 		if err != nil {
 			return err
 		}
-
-		// If a source address is being provided, we're enabling replication into an
-		// existing virtual cluster. It must be inactive, and we'll verify that it
-		// was the cluster from which the one it will replicate was replicated, i.e.
-		// that we're reversing the direction of replication. We will then revert it
-		// to the time they diverged and pick up from there.
-		if alterTenantStmt.ReplicationSourceAddress != nil {
-			return alterTenantRestartReplication(
-				ctx,
-				p,
-				tenInfo,
-				srcAddr,
-				srcTenant,
-				retentionTTLSeconds,
-				alterTenantStmt,
-			)
-		}
-		jobRegistry := p.ExecCfg().JobRegistry
-		if !alterTenantStmt.Options.IsDefault() {
-			// If the statement contains options, then the user provided the ALTER
-			// TENANT ... SET REPLICATION [options] form of the command.
-			return alterTenantSetReplication(ctx, p.InternalSQLTxn(), jobRegistry, options, tenInfo)
-		}
-		if err := checkForActiveIngestionJob(tenInfo); err != nil {
+		if err := ptp.Release(ctx, *ptsID); err != nil {
 			return err
 		}
-		if alterTenantStmt.Cutover != nil {
-			pts := p.ExecCfg().ProtectedTimestampProvider.WithTxn(p.InternalSQLTxn())
-			actualCutoverTime, err := alterTenantJobCutover(
-				ctx, p.InternalSQLTxn(), jobRegistry, pts, alterTenantStmt, tenInfo, cutoverTime)
-			if err != nil {
-				return err
-			}
-			resultsCh <- tree.Datums{eval.TimestampToDecimalDatum(actualCutoverTime)}
-		} else {
-			switch alterTenantStmt.Command {
-			case tree.ResumeJob:
-				if err := jobRegistry.Unpause(ctx, p.InternalSQLTxn(), tenInfo.PhysicalReplicationConsumerJobID); err != nil {
-					return err
-				}
-			case tree.PauseJob:
-				if err := jobRegistry.PauseRequested(ctx, p.InternalSQLTxn(), tenInfo.PhysicalReplicationConsumerJobID,
-					"ALTER VIRTUAL CLUSTER PAUSE REPLICATION"); err != nil {
-					return err
-				}
-			default:
-				return errors.New("unsupported job command in ALTER VIRTUAL CLUSTER REPLICATION")
-			}
+		newPTSID := uuid.MakeV4()
+		if err := ptp.Protect(ctx, jobsprotectedts.MakeRecord(newPTSID, int64(jobID), record.Timestamp,
+			nil /* deprecatedSpans */, jobsprotectedts.Jobs, ptpb.MakeTenantsTarget([]roachpb.TenantID{dstTenantID}),
+		)); err != nil {
+			return err
 		}
-		return nil
+		ptsID = &newPTSID
 	}
-	if alterTenantStmt.Cutover != nil {
-		return fn, alterReplicationCutoverHeader, nil, false, nil
+
+	if err := job.WithTxn(txn).Update(ctx, func(
+		txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		details := md.Payload.GetStreamIngestion()
+		details.DestinationTenantID = dstTenantID
+		details.Span = keys.MakeTenantSpan(dstTenantID)
+		details.ProtectedTimestampRecordID = ptsID
+		ju.UpdatePayload(md.Payload)
+		return nil
+	}); err != nil {
+		return err
 	}
-	return fn, nil, nil, false, nil
+
+	tenInfo.PhysicalReplicationConsumerJobID = jobID
+	return sql.UpdateTenantRecord(ctx, settings, txn, tenInfo)
 }
 
 func alterTenantSetReplication(
 	ctx context.Context,
 	txn isql.Txn,
 	jobRegistry *jobs.Registry,
-	options *resolvedTenantReplicationOptions,
+	options *ResolvedTenantReplicationOptions,
 	tenInfo *mtinfopb.TenantInfo,
 ) error {
+	if _, ok := options.GetSeedFromBackup(); ok {
+		return errors.New("cannot alter SEED_FROM_BACKUP on an existing replication job")
+	}
 
 	if expirationWindow, ok := options.GetExpirationWindow(); ok {
 		if err := alterTenantExpirationWindow(ctx, txn, jobRegistry, expirationWindow, tenInfo); err != nil {
@@ -305,9 +1830,17 @@ func alterTenantRestartReplication(
 	srcAddr string,
 	srcTenant string,
 	retentionTTLSeconds int32,
+	options *ResolvedTenantReplicationOptions,
 	alterTenantStmt *tree.AlterTenantReplication,
+	resultsCh chan<- tree.Datums,
 ) error {
-	dstTenantID, err := roachpb.MakeTenantID(tenInfo.ID)
+	// Check the retention before contacting the source, so that an invalid one
+	// fails fast rather than after a round trip to the source.
+	if err := validateRestartRetention(tenInfo.Name, retentionTTLSeconds); err != nil {
+		return err
+	}
+
+	dstTenantID, err := replicationDestinationTenantID(tenInfo)
 	if err != nil {
 		return err
 	}
@@ -344,20 +1877,26 @@ func alterTenantRestartReplication(
 	}
 	streamAddress = crosscluster.StreamAddress(streamURL.String())
 
-	client, err := streamclient.NewStreamClient(ctx, crosscluster.StreamAddress(srcAddr), p.ExecCfg().InternalDB)
-	if err != nil {
-		return errors.Wrap(err, "creating client")
+	newClient := func(ctx context.Context) (streamclient.Client, error) {
+		return streamclient.NewStreamClient(ctx, crosscluster.StreamAddress(srcAddr), p.ExecCfg().InternalDB)
 	}
-
-	srcID, srcReplicatedFrom, srcActivatedAt, err := client.PriorReplicationDetails(ctx, roachpb.TenantName(srcTenant))
+	srcID, srcReplicatedFrom, srcActivatedAt, err := fetchSourceReplicationDetails(ctx, newClient,
+		roachpb.TenantName(srcTenant), retentionTTLSeconds, p.ExecCfg().Settings.Version,
+		crosscluster.SourceOperationTimeout.Get(&p.ExecCfg().Settings.SV))
 	if err != nil {
-		return errors.CombineErrors(errors.Wrap(err, "fetching prior replication details"), client.Close(ctx))
-	}
-	if err := client.Close(ctx); err != nil {
 		return err
 	}
 
+	// Guard against naming the destination tenant as its own source, e.g. due
+	// to a copy-paste mistake when reversing the direction of replication. The
+	// history IDs only match if the source address refers to this cluster and
+	// the source tenant is the destination tenant.
 	dstID := fmt.Sprintf("%s:%s", p.ExtendedEvalContext().ClusterID, dstTenantID)
+	if srcID == dstID {
+		return errors.Newf("cannot replicate virtual cluster %q (%s) from itself: source virtual cluster %q resolves to the destination",
+			tenInfo.Name, dstTenantID, srcTenant)
+	}
+
 	resumeTS, err := pickReplicationResume(ctx, srcID, srcReplicatedFrom, srcActivatedAt, dstID, tenInfo.PreviousSourceTenant)
 	if err != nil {
 		return err
@@ -367,15 +1906,29 @@ func alterTenantRestartReplication(
 		return err
 	}
 
+	// In validate-only mode, stop short of updating the tenant record and
+	// creating the job, and report what replication would resume from.
+	if alterTenantStmt.Options.ValidateOnly {
+		resultsCh <- tree.Datums{eval.TimestampToDecimalDatum(resumeTS), tree.NewDString(srcID)}
+		return nil
+	}
+
 	const revertFirst = true
 
+	// The tenant record update and the creation of the job it points to are
+	// both written in the plan hook's transaction, so that if creating the job
+	// fails, the transaction is aborted and the tenant record is left as it
+	// was rather than pointing at a job that does not exist. The update is
+	// made on a copy so that the caller's view of the record is likewise left
+	// untouched on failure.
 	jobID := p.ExecCfg().JobRegistry.MakeJobID()
+	updated := *tenInfo
 	// Reset the last revert timestamp.
-	tenInfo.LastRevertTenantTimestamp = hlc.Timestamp{}
-	tenInfo.PhysicalReplicationConsumerJobID = jobID
-	tenInfo.DataState = mtinfopb.DataStateAdd
+	updated.LastRevertTenantTimestamp = hlc.Timestamp{}
+	updated.PhysicalReplicationConsumerJobID = jobID
+	updated.DataState = mtinfopb.DataStateAdd
 	if err := sql.UpdateTenantRecord(ctx, p.ExecCfg().Settings,
-		p.InternalSQLTxn(), tenInfo); err != nil {
+		p.InternalSQLTxn(), &updated); err != nil {
 		return err
 	}
 
@@ -384,13 +1937,14 @@ func alterTenantRestartReplication(
 		revertTo = tenInfo.PreviousSourceTenant.CutoverAsOf
 	}
 
-	return errors.Wrap(createReplicationJob(
+	if err := createReplicationJob(
 		ctx,
 		p,
 		streamAddress,
 		srcTenant,
 		dstTenantID,
 		retentionTTLSeconds,
+		options,
 		resumeTS,
 		revertTo,
 		revertFirst,
@@ -401,7 +1955,215 @@ func alterTenantRestartReplication(
 			ReplicationSourceAddress:    alterTenantStmt.ReplicationSourceAddress,
 			Options:                     alterTenantStmt.Options,
 		},
-	), "creating replication job")
+	); err != nil {
+		return errors.Wrap(err, "creating replication job")
+	}
+	*tenInfo = updated
+	return nil
+}
+
+// fetchSourceReplicationDetails connects to the source using newClient, fetches
+// the prior replication details of the source tenant, and validates the
+// retention and the cluster version of the source. Each interaction with the source is bounded by
+// the given timeout, so that a hung source does not block the statement
+// indefinitely; the client is closed even if one of them times out.
+func fetchSourceReplicationDetails(
+	ctx context.Context,
+	newClient func(context.Context) (streamclient.Client, error),
+	srcTenant roachpb.TenantName,
+	retentionTTLSeconds int32,
+	destVersion clusterversion.Handle,
+	timeout time.Duration,
+) (srcID string, srcReplicatedFrom string, srcActivatedAt hlc.Timestamp, _ error) {
+	var client streamclient.Client
+	if err := timeutil.RunWithTimeout(ctx, "creating stream client", timeout,
+		func(ctx context.Context) (err error) {
+			client, err = newClient(ctx)
+			return err
+		}); err != nil {
+		return "", "", hlc.Timestamp{}, errors.Wrap(err, "creating client")
+	}
+	closeClient := func() error {
+		return timeutil.RunWithTimeout(ctx, "closing stream client", timeout, client.Close)
+	}
+
+	if err := timeutil.RunWithTimeout(ctx, "fetching prior replication details", timeout,
+		func(ctx context.Context) (err error) {
+			srcID, srcReplicatedFrom, srcActivatedAt, err = client.PriorReplicationDetails(ctx, srcTenant)
+			return err
+		}); err != nil {
+		return "", "", hlc.Timestamp{}, errors.CombineErrors(
+			errors.Wrap(err, "fetching prior replication details"), closeClient())
+	}
+	if err := timeutil.RunWithTimeout(ctx, "fetching earliest protectable timestamp", timeout,
+		func(ctx context.Context) error {
+			return validateRetentionAgainstSource(ctx, client, retentionTTLSeconds)
+		}); err != nil {
+		return "", "", hlc.Timestamp{}, errors.CombineErrors(err, closeClient())
+	}
+	if err := timeutil.RunWithTimeout(ctx, "fetching source cluster version", timeout,
+		func(ctx context.Context) error {
+			return validateSourceVersion(ctx, client, destVersion)
+		}); err != nil {
+		return "", "", hlc.Timestamp{}, errors.CombineErrors(err, closeClient())
+	}
+	if err := closeClient(); err != nil {
+		return "", "", hlc.Timestamp{}, err
+	}
+	return srcID, srcReplicatedFrom, srcActivatedAt, nil
+}
+
+// validateSourceVersion returns an error if the source cluster's version is
+// incompatible with the destination's, i.e. if it is newer than the
+// destination's active version, as the destination may not understand data
+// written by it, or older than the minimum version the destination supports.
+func validateSourceVersion(
+	ctx context.Context, client streamclient.Client, destVersion clusterversion.Handle,
+) error {
+	srcVersion, err := client.ClusterVersion(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching the source cluster version")
+	}
+	if srcVersion == (roachpb.Version{}) {
+		return nil
+	}
+	destActive := destVersion.ActiveVersion(ctx).Version
+	if destActive.Less(srcVersion) {
+		return errors.Newf("source cluster version %s is newer than destination cluster version %s",
+			srcVersion, destActive)
+	}
+	if minSupported := destVersion.MinSupportedVersion(); srcVersion.Less(minSupported) {
+		return errors.Newf(
+			"source cluster version %s is older than %s, the minimum version supported by destination cluster version %s",
+			srcVersion, minSupported, destActive)
+	}
+	return nil
+}
+
+// sourceLicenseExpiryWarningWindow is how long before the source cluster's
+// license expires that starting replication from it warns of the expiration.
+var sourceLicenseExpiryWarningWindow = 7 * 24 * time.Hour
+
+// validateSourceLicense checks the expiration of the source cluster's license,
+// as reported by the client. If the license has expired, or expires within
+// sourceLicenseExpiryWarningWindow of now, a notice is sent through
+// noticeSender or, if strict, an error is returned instead. Nothing is checked
+// if the client cannot determine the expiration. A failure to fetch the
+// expiration, e.g. because the source user may not read the license setting,
+// is only surfaced as a notice unless strict.
+func validateSourceLicense(
+	ctx context.Context,
+	client streamclient.Client,
+	noticeSender eval.ClientNoticeSender,
+	now time.Time,
+	strict bool,
+) error {
+	expiration, err := client.LicenseExpiration(ctx)
+	if err != nil {
+		if strict {
+			return errors.Wrap(err, "fetching source cluster license")
+		}
+		noticeSender.BufferClientNotice(ctx,
+			pgnotice.Newf("could not determine the source cluster's license expiration: %v", err))
+		return nil
+	}
+	if expiration.IsZero() || expiration.Sub(now) > sourceLicenseExpiryWarningWindow {
+		return nil
+	}
+	msg := fmt.Sprintf("the source cluster's license expires at %s", expiration)
+	if !now.Before(expiration) {
+		msg = fmt.Sprintf("the source cluster's license expired at %s", expiration)
+	}
+	if strict {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.CCLValidLicenseRequired, "%s", msg),
+			"renew the source cluster's license, or unset "+
+				"physical_replication.consumer.require_valid_source_license to only warn")
+	}
+	noticeSender.BufferClientNotice(ctx, pgnotice.Newf("%s", msg))
+	return nil
+}
+
+// validateRestartRetention checks that the retention of a replication stream
+// being started into an existing virtual cluster is positive. Such a stream
+// resumes from history the virtual cluster already has, which a zero retention
+// would not keep protected while the stream is catching up.
+func validateRestartRetention(tenantName roachpb.TenantName, retentionTTLSeconds int32) error {
+	if retentionTTLSeconds > 0 {
+		return nil
+	}
+	return pgerror.Newf(pgcode.InvalidParameterValue,
+		"cannot start replication into existing virtual cluster %q with a retention of %s: retention must be positive",
+		tenantName, time.Duration(retentionTTLSeconds)*time.Second)
+}
+
+// validateRetentionReduction checks that reducing the retention of a running
+// replication job from oldTTLSeconds to newTTLSeconds keeps it viable: the new
+// retention must exceed the age of the job's frontier, i.e. of its replicated
+// time or, before it has one, of its start time. A shorter retention would
+// immediately expire history the job is still catching up through. Increasing
+// the retention is always allowed.
+func validateRetentionReduction(
+	tenantName roachpb.TenantName,
+	oldTTLSeconds, newTTLSeconds int32,
+	frontier hlc.Timestamp,
+	now hlc.Timestamp,
+) error {
+	if newTTLSeconds >= oldTTLSeconds || frontier.IsEmpty() {
+		return nil
+	}
+	retention := time.Duration(newTTLSeconds) * time.Second
+	age := now.GoTime().Sub(frontier.GoTime())
+	if retention > age {
+		return nil
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.InvalidParameterValue,
+			"cannot reduce the retention of virtual cluster %q to %s: its replication frontier is already %s old",
+			tenantName, retention, age.Truncate(time.Second)),
+		"Choose a retention longer than the replication lag, or wait for replication to catch up.")
+}
+
+// replicationDestinationTenantID returns the ID of the given virtual cluster,
+// or an error explaining that it cannot be a replication destination if that ID
+// is invalid or is the system tenant's.
+func replicationDestinationTenantID(tenInfo *mtinfopb.TenantInfo) (roachpb.TenantID, error) {
+	dstTenantID, err := roachpb.MakeTenantID(tenInfo.ID)
+	if err != nil {
+		return roachpb.TenantID{}, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+			"virtual cluster %q (%d) cannot be a replication destination", tenInfo.Name, tenInfo.ID)
+	}
+	if dstTenantID.IsSystem() {
+		return roachpb.TenantID{}, pgerror.Newf(pgcode.InvalidParameterValue,
+			"virtual cluster %q (%d) cannot be a replication destination: it is the system virtual cluster",
+			tenInfo.Name, tenInfo.ID)
+	}
+	return dstTenantID, nil
+}
+
+// validateRetentionAgainstSource returns an error if the given retention would
+// require the source cluster to protect data older than its earliest
+// protectable timestamp, i.e. data that it may have already garbage collected.
+func validateRetentionAgainstSource(
+	ctx context.Context, client streamclient.Client, retentionTTLSeconds int32,
+) error {
+	floor, now, err := client.EarliestProtectableTimestamp(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching the source's earliest protectable timestamp")
+	}
+	if floor.IsEmpty() {
+		return nil
+	}
+	required := now.AddDuration(-time.Duration(retentionTTLSeconds) * time.Second)
+	if required.Less(floor) {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"retention of %s would require the source to protect data as of %s, "+
+					"but its earliest protectable timestamp is %s",
+				time.Duration(retentionTTLSeconds)*time.Second, required, floor),
+			"lower the RETENTION option, or raise the GC TTL of the source's tenant ranges")
+	}
+	return nil
 }
 
 // pickReplicationResume picks the timestamp to which dst can be reverted to
@@ -504,19 +2266,27 @@ func checkReplicationStartTime(
 
 // alterTenantJobCutover returns the cutover timestamp that was used to initiate
 // the cutover process - if the command is 'ALTER VIRTUAL CLUSTER .. COMPLETE REPLICATION
-// TO LATEST' then the frontier high water timestamp is used.
+// TO LATEST' then the frontier high water timestamp is used. If the frontier
+// has not yet advanced, the replication start time is used instead and a notice
+// is sent through noticeSender.
 func alterTenantJobCutover(
 	ctx context.Context,
 	txn isql.Txn,
 	jobRegistry *jobs.Registry,
 	ptp protectedts.Storage,
+	noticeSender eval.ClientNoticeSender,
+	sv *settings.Values,
 	alterTenantStmt *tree.AlterTenantReplication,
 	tenInfo *mtinfopb.TenantInfo,
 	cutoverTime hlc.Timestamp,
+	clockSkewTolerance time.Duration,
 ) (_ hlc.Timestamp, err error) {
 	if alterTenantStmt == nil || alterTenantStmt.Cutover == nil {
 		return hlc.Timestamp{}, errors.AssertionFailedf("unexpected nil ALTER VIRTUAL CLUSTER cutover expression")
 	}
+	if ptp == nil {
+		return hlc.Timestamp{}, errors.New("protected timestamp subsystem unavailable")
+	}
 
 	defer func() {
 		if err == nil {
@@ -534,15 +2304,35 @@ func alterTenantJobCutover(
 		return hlc.Timestamp{}, errors.Newf("job with id %d is not a stream ingestion job", job.ID())
 	}
 	progress := job.Progress()
+	if autoCutover := details.AutoCutoverTime; !autoCutover.IsEmpty() {
+		return hlc.Timestamp{}, errors.WithHint(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot complete replication of virtual cluster %q: it is scheduled to cut over automatically to %s",
+				tenantName, autoCutover.GoTime()),
+			"wait for the automatic cutover to complete, or cancel it with "+
+				"ALTER VIRTUAL CLUSTER ... SET REPLICATION AUTO_CUTOVER = NULL")
+	}
+	if err := validateInitialScanComplete(tenantName, progress.GetStreamIngest()); err != nil {
+		return hlc.Timestamp{}, err
+	}
 
+	replicatedTime := replicationutils.ReplicatedTimeFromProgress(&progress)
 	if alterTenantStmt.Cutover.Latest {
-		replicatedTime := replicationutils.ReplicatedTimeFromProgress(&progress)
-		if replicatedTime.IsEmpty() {
-			cutoverTime = details.ReplicationStartTime
-		} else {
-			cutoverTime = replicatedTime
+		var notice pgnotice.Notice
+		cutoverTime, notice = latestCutoverTime(tenantName, replicatedTime, details.ReplicationStartTime)
+		if notice != nil {
+			noticeSender.BufferClientNotice(ctx, notice)
 		}
 	}
+	if err := validateCutoverLogical(cutoverTime, replicatedTime); err != nil {
+		return hlc.Timestamp{}, err
+	}
+	if err := validateCutoverDataLoss(
+		cutoverTime, replicatedTime, crosscluster.CutoverDataLossThreshold.Get(sv),
+		alterTenantStmt.Cutover.Options.AllowDataLoss,
+	); err != nil {
+		return hlc.Timestamp{}, err
+	}
 
 	// TODO(ssd): We could use the replication manager here, but
 	// that embeds a priviledge check which is already completed.
@@ -555,47 +2345,254 @@ func alterTenantJobCutover(
 	if stats.IngestionDetails.ProtectedTimestampRecordID == nil {
 		return hlc.Timestamp{}, errors.Newf("replicated tenant %q (%d) has not yet recorded a retained timestamp",
 			tenantName, tenInfo.ID)
-	} else {
-		record, err := ptp.GetRecord(ctx, *stats.IngestionDetails.ProtectedTimestampRecordID)
-		if err != nil {
-			return hlc.Timestamp{}, err
-		}
-		if cutoverTime.Less(record.Timestamp) {
-			return hlc.Timestamp{}, errors.Newf("cutover time %s is before earliest safe cutover time %s",
-				cutoverTime, record.Timestamp)
-		}
+	} else if err := validateCutoverTime(
+		ctx, ptp, *stats.IngestionDetails.ProtectedTimestampRecordID, cutoverTime, clockSkewTolerance,
+	); err != nil {
+		return hlc.Timestamp{}, err
 	}
-	if err := applyCutoverTime(ctx, job, txn, cutoverTime); err != nil {
+	if err := applyCutoverTime(
+		ctx, job, txn, ptp, cutoverTime, clockSkewTolerance,
+		alterTenantStmt.Cutover.Options.DrainBeforeCutover,
+	); err != nil {
 		return hlc.Timestamp{}, err
 	}
 
 	return cutoverTime, nil
 }
 
+// validateInitialScanComplete returns an error if the consumer job with the
+// given progress has yet to complete its initial scan, in which case the
+// destination virtual cluster holds an incomplete copy of the source's data that
+// cannot be cut over to at any time. The initial scan is complete once the job
+// has a replicated time, or every span of its checkpoint has been resolved.
+func validateInitialScanComplete(
+	tenantName roachpb.TenantName, progress *jobspb.StreamIngestionProgress,
+) error {
+	if !progress.ReplicatedTime.IsEmpty() {
+		return nil
+	}
+	pct, ok := initialScanProgress(progress)
+	if ok && pct == 100 {
+		return nil
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot complete replication of virtual cluster %q: its initial scan has not completed",
+			tenantName),
+		"wait for the initial scan to complete; SHOW REPLICATION STATS reports its progress")
+}
+
+// latestCutoverTime returns the timestamp that 'COMPLETE REPLICATION TO LATEST'
+// resolves to. This is the replicated time, unless the frontier has not yet
+// advanced, in which case the replication start time is returned along with a
+// notice explaining the fallback.
+func latestCutoverTime(
+	tenantName roachpb.TenantName, replicatedTime, replicationStartTime hlc.Timestamp,
+) (hlc.Timestamp, pgnotice.Notice) {
+	if !replicatedTime.IsEmpty() {
+		return replicatedTime, nil
+	}
+	return replicationStartTime, pgnotice.Newf(
+		"replicated time of virtual cluster %q has not advanced yet; cutting over to the replication start time %s",
+		tenantName, replicationStartTime.GoTime())
+}
+
+// validateCutoverLogical returns an error if the cutover time carries a logical
+// component that replication cannot guarantee to resolve. The replicated time
+// only promises that everything at or below it has been ingested, so a logical
+// tick above it may fall between the timestamps the frontier advances through.
+// Cutover times with a logical component are therefore only accepted if they
+// have already been replicated.
+func validateCutoverLogical(cutoverTime, replicatedTime hlc.Timestamp) error {
+	if cutoverTime.Logical == 0 || cutoverTime.LessEq(replicatedTime) {
+		return nil
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.InvalidParameterValue,
+			"cutover time %s has a logical component and is above the replicated time %s",
+			cutoverTime, replicatedTime),
+		"specify a cutover time without a logical component, or one at or below the replicated time")
+}
+
+// validateCutoverDataLoss returns an error if the cutover time is more than the
+// given threshold behind the replicated time, unless the loss of the data
+// replicated in between has been acknowledged with WITH ALLOW_DATA_LOSS. This
+// guards against mistyped cutover times that would silently discard data. A
+// zero threshold disables the check.
+func validateCutoverDataLoss(
+	cutoverTime, replicatedTime hlc.Timestamp, threshold time.Duration, allowDataLoss bool,
+) error {
+	if allowDataLoss || threshold == 0 || replicatedTime.IsEmpty() {
+		return nil
+	}
+	gap := replicatedTime.GoTime().Sub(cutoverTime.GoTime())
+	if gap <= threshold {
+		return nil
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.InvalidParameterValue,
+			"cutover time %s is %s behind the replicated time %s, which would discard the data replicated in between",
+			cutoverTime, gap, replicatedTime),
+		"specify WITH ALLOW_DATA_LOSS to cut over to this time regardless")
+}
+
+// validateCutoverTime returns an error if the cutover time is below the
+// timestamp currently protected by the given protected timestamp record, less
+// the given clock skew tolerance.
+func validateCutoverTime(
+	ctx context.Context,
+	ptp protectedts.Storage,
+	recordID uuid.UUID,
+	cutoverTime hlc.Timestamp,
+	clockSkewTolerance time.Duration,
+) error {
+	record, err := ptp.GetRecord(ctx, recordID)
+	if err != nil {
+		return err
+	}
+	floor := record.Timestamp.AddDuration(-clockSkewTolerance)
+	if cutoverTime.Less(floor) {
+		if clockSkewTolerance == 0 {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"cutover time %s is before earliest safe cutover time %s", cutoverTime, record.Timestamp)
+		}
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"cutover time %s is before earliest safe cutover time %s, even with a clock skew tolerance of %s",
+			cutoverTime, record.Timestamp, clockSkewTolerance)
+	}
+	return nil
+}
+
+// maxClockSkewTolerance bounds the CLOCK_SKEW_TOLERANCE of a cutover. The
+// tolerance is meant to absorb the skew between the clocks of the systems
+// involved, which is far below this; a larger one would be a mistake.
+const maxClockSkewTolerance = 10 * time.Second
+
+// evalClockSkewTolerance evaluates the CLOCK_SKEW_TOLERANCE option of a
+// cutover, checking that it is non-negative and at most maxClockSkewTolerance.
+func evalClockSkewTolerance(
+	ctx context.Context, eval exprutil.Evaluator, expr tree.Expr,
+) (time.Duration, error) {
+	dur, err := eval.Duration(ctx, expr)
+	if err != nil {
+		return 0, err
+	}
+	tolerance := time.Duration(dur.Nanos())
+	if dur.Months != 0 || dur.Days != 0 || tolerance < 0 || tolerance > maxClockSkewTolerance {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"CLOCK_SKEW_TOLERANCE should be between 0s and %s", maxClockSkewTolerance)
+	}
+	return tolerance, nil
+}
+
+// resolveCutoverEvent returns the time recorded for the named event in the
+// table configured by the physical_replication.consumer.cutover_events_table
+// cluster setting. The events are written by tooling outside of the database
+// to record, e.g., when a decision to fail over was made.
+func resolveCutoverEvent(
+	ctx context.Context, txn isql.Txn, sv *settings.Values, eventName string,
+) (hlc.Timestamp, error) {
+	tableName := crosscluster.CutoverEventsTable.Get(sv)
+	if tableName == "" {
+		return hlc.Timestamp{}, errors.WithHintf(
+			errors.Newf("cannot resolve cutover event %q: no cutover events table is configured", eventName),
+			"set the %s cluster setting", crosscluster.CutoverEventsTable.Name())
+	}
+	tn, err := parser.ParseQualifiedTableName(tableName)
+	if err != nil {
+		return hlc.Timestamp{}, errors.Wrapf(err, "invalid cutover events table %q", tableName)
+	}
+
+	row, err := txn.QueryRowEx(ctx, "resolve-cutover-event", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		fmt.Sprintf(`SELECT event_time FROM %s WHERE name = $1`, tn.String()),
+		eventName)
+	if err != nil {
+		return hlc.Timestamp{}, errors.Wrapf(err, "resolving cutover event %q", eventName)
+	}
+	if row == nil {
+		return hlc.Timestamp{}, errors.Newf("unknown cutover event %q", eventName)
+	}
+
+	switch t := row[0].(type) {
+	case *tree.DDecimal:
+		return hlc.DecimalToHLC(&t.Decimal)
+	case *tree.DTimestampTZ:
+		return hlc.Timestamp{WallTime: t.UnixNano()}, nil
+	default:
+		return hlc.Timestamp{}, errors.Newf("cutover event %q has a time of unsupported type %s",
+			eventName, row[0].ResolvedType())
+	}
+}
+
 // applyCutoverTime modifies the consumer job record with a cutover time and
 // unpauses the job if necessary.
+//
+// The cutover time is validated again against the job's protected timestamp
+// record as part of the job update, since the record may have advanced after
+// the caller's own validation.
+//
+// If drainBeforeCutover is set, the job applies the data it has received but
+// not yet applied before reverting to the cutover time.
 func applyCutoverTime(
-	ctx context.Context, job *jobs.Job, txn isql.Txn, cutoverTimestamp hlc.Timestamp,
+	ctx context.Context,
+	job *jobs.Job,
+	txn isql.Txn,
+	ptp protectedts.Storage,
+	cutoverTimestamp hlc.Timestamp,
+	clockSkewTolerance time.Duration,
+	drainBeforeCutover bool,
 ) error {
 	log.Infof(ctx, "adding cutover time %s to job record", cutoverTimestamp)
 	return job.WithTxn(txn).Update(ctx, func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
 		progress := md.Progress.GetStreamIngest()
 		details := md.Payload.GetStreamIngestion()
-		if progress.ReplicationStatus == jobspb.ReplicationCuttingOver {
-			return errors.Newf("job %d already started cutting over to timestamp %s",
-				job.ID(), progress.CutoverTime)
+		if err := checkNoCutoverInProgress(job.ID(), progress, cutoverTimestamp); err != nil {
+			return err
+		}
+		if details.ProtectedTimestampRecordID != nil {
+			if err := validateCutoverTime(
+				ctx, ptp, *details.ProtectedTimestampRecordID, cutoverTimestamp, clockSkewTolerance,
+			); err != nil {
+				return err
+			}
 		}
 
 		progress.ReplicationStatus = jobspb.ReplicationPendingCutover
 		// Update the sentinel being polled by the stream ingestion job to
 		// check if a complete has been signaled.
 		progress.CutoverTime = cutoverTimestamp
+		progress.DrainBeforeCutover = drainBeforeCutover
 		progress.RemainingCutoverSpans = roachpb.Spans{details.Span}
 		ju.UpdateProgress(md.Progress)
 		return ju.Unpaused(ctx, md)
 	})
 }
 
+// checkNoCutoverInProgress returns an error if the job has started cutting
+// over, in which case its cutover time can no longer be changed: the revert to
+// that time may already have been applied to some of the spans, with the rest
+// tracked in RemainingCutoverSpans, and overwriting either mid-flight would
+// leave the tenant reverted to a mix of the two times. The cutover time can
+// only be changed while the cutover is pending.
+func checkNoCutoverInProgress(
+	jobID jobspb.JobID, progress *jobspb.StreamIngestionProgress, cutoverTime hlc.Timestamp,
+) error {
+	if progress.ReplicationStatus != jobspb.ReplicationCuttingOver {
+		return nil
+	}
+	err := errors.Newf("job %d already started cutting over to timestamp %s",
+		jobID, progress.CutoverTime)
+	if remaining := len(progress.RemainingCutoverSpans); remaining > 0 &&
+		!progress.CutoverTime.Equal(cutoverTime) {
+		err = errors.Newf("job %d already started cutting over to timestamp %s and has %d spans left "+
+			"to revert; cannot change the cutover time to %s",
+			jobID, progress.CutoverTime, remaining, cutoverTime)
+	}
+	return errors.WithHint(err,
+		"wait for the cutover in progress to complete, or cancel the replication job")
+}
+
 func alterTenantExpirationWindow(
 	ctx context.Context,
 	txn isql.Txn,
@@ -629,19 +2626,142 @@ func alterTenantConsumerOptions(
 	ctx context.Context,
 	txn isql.Txn,
 	jobRegistry *jobs.Registry,
-	options *resolvedTenantReplicationOptions,
+	options *ResolvedTenantReplicationOptions,
 	tenInfo *mtinfopb.TenantInfo,
 ) error {
-	return jobRegistry.UpdateJobWithTxn(ctx, tenInfo.PhysicalReplicationConsumerJobID, txn,
+	// changed holds the options whose value was actually altered. Every option
+	// persisted below records whether it changed, so that each is counted by
+	// the OptionChanges metric.
+	var changed []string
+	if err := jobRegistry.UpdateJobWithTxn(ctx, tenInfo.PhysicalReplicationConsumerJobID, txn,
 		func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+			// Options only affect ongoing ingestion, which is about to stop once
+			// a cutover has been requested.
+			switch status := md.Progress.GetStreamIngest().ReplicationStatus; status {
+			case jobspb.ReplicationPendingCutover, jobspb.ReplicationCuttingOver:
+				return errors.WithHint(
+					pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+						"cannot alter replication options of virtual cluster %q: replication job %d is finishing its cutover (status: %s)",
+						tenInfo.Name, md.ID, status),
+					"Options no longer apply once a cutover has been requested; wait for the cutover to complete.")
+			}
 			streamIngestionDetails := md.Payload.GetStreamIngestion()
+			changed = changed[:0]
+			record := func(option string, differs bool) {
+				if differs {
+					changed = append(changed, option)
+				}
+			}
 			if ret, ok := options.GetRetention(); ok {
+				frontier := md.Progress.GetStreamIngest().ReplicatedTime
+				if frontier.IsEmpty() {
+					frontier = streamIngestionDetails.ReplicationStartTime
+				}
+				if err := validateRetentionReduction(tenInfo.Name, streamIngestionDetails.ReplicationTTLSeconds,
+					ret, frontier, txn.KV().ReadTimestamp()); err != nil {
+					return err
+				}
+				record("RETENTION", streamIngestionDetails.ReplicationTTLSeconds != ret)
 				streamIngestionDetails.ReplicationTTLSeconds = ret
 			}
+			if ret, ok := options.GetInitialRetention(); ok {
+				record("INITIAL_RETENTION", streamIngestionDetails.InitialReplicationTTLSeconds != ret)
+				streamIngestionDetails.InitialReplicationTTLSeconds = ret
+			}
+			if err := validateInitialRetention(streamIngestionDetails.InitialReplicationTTLSeconds,
+				streamIngestionDetails.ReplicationTTLSeconds); err != nil {
+				return err
+			}
+			if priority, ok := options.GetPriority(); ok {
+				record("PRIORITY", streamIngestionDetails.Priority != priority)
+				streamIngestionDetails.Priority = priority
+			}
+			if partitions, ok := options.GetResumePartitions(); ok {
+				if err := validateResumePartitions(partitions, txn.KV().ReadTimestamp(),
+					streamIngestionDetails.ReplicationTTLSeconds); err != nil {
+					return err
+				}
+				record("RESUME_PARTITIONS", !maps.Equal(streamIngestionDetails.ResumePartitions, partitions))
+				streamIngestionDetails.ResumePartitions = partitions
+			}
+			if owner, ok := options.GetOwner(); ok {
+				record("OWNER", streamIngestionDetails.Owner != owner)
+				streamIngestionDetails.Owner = owner
+			}
+			if mode, ok := options.GetServiceModeOnComplete(); ok {
+				record("SERVICE_MODE_ON_COMPLETE", streamIngestionDetails.ServiceModeOnComplete != mode)
+				streamIngestionDetails.ServiceModeOnComplete = mode
+			}
+			if percent, ok := options.GetPauseOnDiskFull(); ok {
+				record("PAUSE_ON_DISK_FULL", streamIngestionDetails.PauseOnDiskFull != percent)
+				streamIngestionDetails.PauseOnDiskFull = percent
+			}
+			if streams, ok := options.GetMaxPartitionStreams(); ok {
+				record("MAX_PARTITION_STREAMS", streamIngestionDetails.MaxPartitionStreams != streams)
+				streamIngestionDetails.MaxPartitionStreams = streams
+			}
+			if interval, ok := options.GetPTSAdvanceInterval(); ok {
+				record("PTS_ADVANCE_INTERVAL", streamIngestionDetails.PTSAdvanceInterval != interval)
+				streamIngestionDetails.PTSAdvanceInterval = interval
+			}
+			if verify, ok := options.GetVerifyChecksums(); ok {
+				record("VERIFY_CHECKSUMS", streamIngestionDetails.VerifyChecksums != verify)
+				streamIngestionDetails.VerifyChecksums = verify
+			}
+			if sink, ok := options.GetEventSink(); ok {
+				record("EVENT_SINK", streamIngestionDetails.EventSink != sink)
+				streamIngestionDetails.EventSink = sink
+			}
+			if nodeIDs, ok := options.GetTargetNodes(); ok {
+				record("TARGET_NODES", !slices.Equal(streamIngestionDetails.TargetNodeIDs, nodeIDs))
+				streamIngestionDetails.TargetNodeIDs = nodeIDs
+			}
+			if onError, ok := options.GetOnError(); ok {
+				record("ON_ERROR", streamIngestionDetails.OnError != onError)
+				streamIngestionDetails.OnError = onError
+			}
+			if ts, ok := options.GetAutoCutover(); ok {
+				record("AUTO_CUTOVER", streamIngestionDetails.AutoCutoverTime != ts)
+				streamIngestionDetails.AutoCutoverTime = ts
+			}
+			if timeout, ok := options.GetIdleTimeout(); ok {
+				record("IDLE_TIMEOUT", streamIngestionDetails.IdleTimeout != timeout)
+				streamIngestionDetails.IdleTimeout = timeout
+			}
+			if mode, ok := options.GetSpanConfigMode(); ok {
+				record("SPAN_CONFIG", streamIngestionDetails.SpanConfigMode != mode)
+				streamIngestionDetails.SpanConfigMode = mode
+			}
+			if key, ok := options.GetEncryptionKey(); ok {
+				record("ENCRYPTION_KEY", streamIngestionDetails.EncryptionKey != key)
+				streamIngestionDetails.EncryptionKey = key
+			}
+			if interval, ok := options.GetCheckpointInterval(); ok {
+				record("CHECKPOINT_INTERVAL", streamIngestionDetails.CheckpointInterval != interval)
+				streamIngestionDetails.CheckpointInterval = interval
+			}
+			if size, ok := options.GetSourceConnPool(); ok {
+				record("SOURCE_CONN_POOL", streamIngestionDetails.SourceConnPool != size)
+				streamIngestionDetails.SourceConnPool = size
+			}
+			if mode, ok := options.GetIngestMode(); ok {
+				record("INGEST_MODE", streamIngestionDetails.IngestMode != mode)
+				streamIngestionDetails.IngestMode = mode
+			}
 			ju.UpdatePayload(md.Payload)
 			return nil
-		})
+		}); err != nil {
+		return err
+	}
 
+	// Record which options were actually changed so that operators can track
+	// configuration drift of replication jobs over time.
+	if m, ok := jobRegistry.MetricsStruct().StreamIngest.(*Metrics); ok {
+		for _, option := range changed {
+			m.recordOptionChange(option)
+		}
+	}
+	return nil
 }
 
 func init() {
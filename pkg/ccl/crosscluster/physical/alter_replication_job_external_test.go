@@ -0,0 +1,89 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package physical_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster/physical"
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEvalTenantReplicationOptionsExported exercises the exported option
+// evaluation API from outside the package.
+func TestEvalTenantReplicationOptionsExported(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	defer evalCtx.Stop(ctx)
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	exprEval := exprutil.MakeEvaluator("test", &semaCtx, &evalCtx)
+
+	var zoneGCTTL physical.ZoneGCTTLResolver = func(
+		_ context.Context, zone zonepb.NamedZone,
+	) (int32, error) {
+		if zone != zonepb.DefaultZoneName {
+			return 0, errors.Newf("no zone config for %s", zone)
+		}
+		return 14400, nil
+	}
+	evalOptions := func(
+		options tree.TenantReplicationOptions,
+	) (*physical.ResolvedTenantReplicationOptions, error) {
+		return physical.EvalTenantReplicationOptions(ctx, options, exprEval, &evalCtx, &semaCtx, zoneGCTTL, "test")
+	}
+
+	options, err := evalOptions(tree.TenantReplicationOptions{
+		Retention:        tree.NewStrVal("2h"),
+		ExpirationWindow: tree.NewStrVal("30m"),
+		Priority:         tree.NewStrVal("HIGH"),
+		Owner:            tree.NewStrVal("team-dr"),
+	})
+	require.NoError(t, err)
+	retention, ok := options.GetRetention()
+	require.True(t, ok)
+	require.Equal(t, int32(2*60*60), retention)
+	expirationWindow, ok := options.GetExpirationWindow()
+	require.True(t, ok)
+	require.Equal(t, 30*time.Minute, expirationWindow)
+	priority, ok := options.GetPriority()
+	require.True(t, ok)
+	require.Equal(t, "high", priority)
+	owner, ok := options.GetOwner()
+	require.True(t, ok)
+	require.Equal(t, "team-dr", owner)
+	require.True(t, options.DestinationOptionsSet())
+
+	options, err = evalOptions(tree.TenantReplicationOptions{
+		RetentionFromZone: tree.NewStrVal("default"),
+	})
+	require.NoError(t, err)
+	retention, ok = options.GetRetention()
+	require.True(t, ok)
+	require.Equal(t, int32(14400), retention)
+
+	options, err = evalOptions(tree.TenantReplicationOptions{})
+	require.NoError(t, err)
+	require.False(t, options.DestinationOptionsSet())
+
+	_, err = evalOptions(tree.TenantReplicationOptions{Priority: tree.NewStrVal("urgent")})
+	require.ErrorContains(t, err, "invalid PRIORITY")
+}
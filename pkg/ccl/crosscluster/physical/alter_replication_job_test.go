@@ -11,28 +11,53 @@ package physical
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster"
 	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster/replicationtestutils"
+	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster/replicationutils"
+	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster/streamclient"
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
 	"github.com/cockroachdb/cockroach/pkg/cloud/nodelocal"
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
@@ -60,6 +85,115 @@ func TestAlterTenantCompleteToTime(t *testing.T) {
 	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
 }
 
+// TestAlterTenantServiceModeOnComplete verifies that the destination tenant is
+// put in the service mode given by SERVICE_MODE_ON_COMPLETE once the cutover
+// completes.
+func TestAlterTenantServiceModeOnComplete(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	args.RetentionTTLSeconds = 60 * 60
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	c.DestSysSQL.Exec(t, c.BuildCreateTenantQuery("")+", SERVICE_MODE_ON_COMPLETE = 'shared'")
+	producerJobID, ingestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, args.DestTenantName)
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	details := jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion()
+	require.Equal(t, mtinfopb.ServiceModeShared, details.ServiceModeOnComplete)
+
+	serviceMode := func() string {
+		var mode string
+		c.DestSysSQL.QueryRow(t, `SELECT service_mode FROM [SHOW VIRTUAL CLUSTER $1]`,
+			args.DestTenantName).Scan(&mode)
+		return mode
+	}
+	require.Equal(t, "none", serviceMode())
+
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+	c.Cutover(ctx, producerJobID, ingestionJobID, time.Time{}, false)
+	require.Equal(t, "shared", serviceMode())
+}
+
+// TestAlterTenantCompleteToLogicalTimestamp verifies that a cutover timestamp
+// with a logical component is rejected if it is above the replicated time, but
+// accepted otherwise.
+func TestAlterTenantCompleteToLogicalTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	future := c.DestSysServer.Clock().Now().Add(time.Hour.Nanoseconds(), 1)
+	c.DestSysSQL.ExpectErr(t, "has a logical component and is above the replicated time",
+		`ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, future.AsOfSystemTime())
+
+	replicatedTime := replicationutils.ReplicatedTimeFromProgress(
+		jobutils.GetJobProgress(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)))
+	past := hlc.Timestamp{WallTime: replicatedTime.WallTime - 1, Logical: 1}
+	var cutoverStr string
+	c.DestSysSQL.QueryRow(t, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, past.AsOfSystemTime()).Scan(&cutoverStr)
+	cutoverOutput := replicationtestutils.DecimalTimeToHLC(t, cutoverStr)
+	require.Equal(t, past, cutoverOutput)
+	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+}
+
+// TestAlterTenantCompleteToEvent verifies that a cutover time can be resolved
+// from a named event recorded in the configured cutover events table.
+func TestAlterTenantCompleteToEvent(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	c.DestSysSQL.ExpectErr(t, `no cutover events table is configured`,
+		`ALTER TENANT $1 COMPLETE REPLICATION TO EVENT 'failover'`, args.DestTenantName)
+
+	c.DestSysSQL.Exec(t, `CREATE TABLE defaultdb.cutover_events (name STRING PRIMARY KEY, event_time DECIMAL)`)
+	c.DestSysSQL.Exec(t, `SET CLUSTER SETTING physical_replication.consumer.cutover_events_table = 'defaultdb.cutover_events'`)
+
+	c.DestSysSQL.ExpectErr(t, `unknown cutover event "failover"`,
+		`ALTER TENANT $1 COMPLETE REPLICATION TO EVENT 'failover'`, args.DestTenantName)
+
+	var eventTimeStr string
+	c.DestSysSQL.QueryRow(t, `INSERT INTO defaultdb.cutover_events VALUES ('failover', cluster_logical_timestamp())
+RETURNING event_time::STRING`).Scan(&eventTimeStr)
+	eventTime := replicationtestutils.DecimalTimeToHLC(t, eventTimeStr)
+
+	var cutoverStr string
+	c.DestSysSQL.QueryRow(t, `ALTER TENANT $1 COMPLETE REPLICATION TO EVENT 'failover'`,
+		args.DestTenantName).Scan(&cutoverStr)
+	require.Equal(t, eventTime, replicationtestutils.DecimalTimeToHLC(t, cutoverStr))
+	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+}
+
 func TestAlterTenantCompleteToLatest(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -137,10 +271,18 @@ func TestAlterTenantPauseResume(t *testing.T) {
 	c.DestSysSQL.Exec(t, `ALTER TENANT $1 PAUSE REPLICATION`, args.DestTenantName)
 	jobutils.WaitForJobToPause(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
 
+	// Pausing an already paused job is a no-op.
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 PAUSE REPLICATION`, args.DestTenantName)
+	jobutils.WaitForJobToPause(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
 	// Unpause the replication job.
 	c.DestSysSQL.Exec(t, `ALTER TENANT $1 RESUME REPLICATION`, args.DestTenantName)
 	jobutils.WaitForJobToRun(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
 
+	// Resuming an already running job is a no-op.
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 RESUME REPLICATION`, args.DestTenantName)
+	jobutils.WaitForJobToRun(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
 	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
 	var cutoverTime time.Time
 	c.DestSysSQL.QueryRow(t, "SELECT clock_timestamp()").Scan(&cutoverTime)
@@ -178,312 +320,416 @@ func TestAlterTenantPauseResume(t *testing.T) {
 	})
 }
 
-// TestAlterTenantUpdateExistingCutoverTime verifies we can set a new cutover
-// time if the cutover process did not start yet.
-func TestAlterTenantUpdateExistingCutoverTime(t *testing.T) {
+// TestAlterAllTenantsPauseResumeReplication verifies that ALTER VIRTUAL CLUSTER
+// ALL PAUSE/RESUME REPLICATION alters the replication job of every replicating
+// tenant, and skips jobs that are already in the requested state or that are
+// still referenced by their tenant after they were canceled.
+func TestAlterAllTenantsPauseResumeReplication(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 
 	ctx := context.Background()
 	args := replicationtestutils.DefaultTenantStreamingClustersArgs
-
 	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
 	defer cleanup()
 
-	getTenantStatus := func() string {
-		var status string
-		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT data_state FROM [SHOW TENANT %s]",
-			c.Args.DestTenantName)).Scan(&status)
-		return status
+	_, ingestionJobID := c.StartStreamReplication(ctx)
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	const otherDestTenantName = roachpb.TenantName("destination-2")
+	c.DestSysSQL.Exec(t, fmt.Sprintf("CREATE VIRTUAL CLUSTER %q FROM REPLICATION OF %q ON $1",
+		otherDestTenantName, args.SrcTenantName), c.SrcURL.String())
+	_, otherIngestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, otherDestTenantName)
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(otherIngestionJobID))
+
+	// A canceled job clears its tenant's reference to it; restore the reference
+	// to leave the tenant pointing at a job in a terminal state.
+	const staleDestTenantName = roachpb.TenantName("destination-3")
+	c.DestSysSQL.Exec(t, fmt.Sprintf("CREATE VIRTUAL CLUSTER %q FROM REPLICATION OF %q ON $1",
+		staleDestTenantName, args.SrcTenantName), c.SrcURL.String())
+	_, staleIngestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, staleDestTenantName)
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(staleIngestionJobID))
+	c.DestSysSQL.Exec(t, `CANCEL JOB $1`, staleIngestionJobID)
+	jobutils.WaitForJobToCancel(t, c.DestSysSQL, jobspb.JobID(staleIngestionJobID))
+	execCfg := c.DestSysServer.ExecutorConfig().(sql.ExecutorConfig)
+	require.NoError(t, execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		tenInfo, err := sql.GetTenantRecordByName(ctx, execCfg.Settings, txn, staleDestTenantName)
+		if err != nil {
+			return err
+		}
+		tenInfo.PhysicalReplicationConsumerJobID = jobspb.JobID(staleIngestionJobID)
+		return sql.UpdateTenantRecord(ctx, execCfg.Settings, txn, tenInfo)
+	}))
+
+	jobIDs := []int{ingestionJobID, otherIngestionJobID, staleIngestionJobID}
+	expectResults := func(stmt string, results ...string) {
+		rows := c.DestSysSQL.QueryStr(t, stmt)
+		require.Len(t, rows, len(jobIDs))
+		for i, row := range rows {
+			require.Equal(t, fmt.Sprint(jobIDs[i]), row[2])
+			require.Equal(t, results[i], row[3])
+		}
 	}
-	getCutoverTime := func() hlc.Timestamp {
-		var cutoverStr string
-		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT cutover_time FROM [SHOW TENANT %s WITH REPLICATION STATUS]",
-			c.Args.DestTenantName)).Scan(&cutoverStr)
-		cutoverOutput := replicationtestutils.DecimalTimeToHLC(t, cutoverStr)
-		return cutoverOutput
+
+	expectResults(`ALTER VIRTUAL CLUSTER ALL PAUSE REPLICATION`,
+		"pause requested", "pause requested", "skipped: job is canceled")
+	for _, jobID := range jobIDs[:2] {
+		jobutils.WaitForJobToPause(t, c.DestSysSQL, jobspb.JobID(jobID))
 	}
 
-	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+	// Resume one of the jobs so that only the other one is altered by the
+	// resume below.
+	c.DestSysSQL.Exec(t, `ALTER VIRTUAL CLUSTER $1 RESUME REPLICATION`, otherDestTenantName)
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(otherIngestionJobID))
 
-	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	expectResults(`ALTER VIRTUAL CLUSTER ALL RESUME REPLICATION`,
+		"resumed", "skipped: job is already running", "skipped: job is canceled")
+	for _, jobID := range jobIDs[:2] {
+		jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(jobID))
+	}
+}
+
+// TestAlterTenantReplicationSerialized verifies that ALTER VIRTUAL CLUSTER
+// REPLICATION statements against the same tenant are serialized: a statement
+// blocks on the tenant record locked by a concurrent one until the latter's
+// transaction commits.
+func TestAlterTenantReplicationSerialized(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	_, ingestionJobID := c.StartStreamReplication(ctx)
 	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
 
-	replicatedTimeTarget := c.SrcCluster.Server(0).Clock().Now()
-	c.WaitUntilReplicatedTime(replicatedTimeTarget, jobspb.JobID(ingestionJobID))
+	// Pause the job in a transaction that is left open, holding the lock on
+	// the tenant record.
+	txn, err := c.DestSysServer.SQLConn(t).BeginTx(ctx, nil /* opts */)
+	require.NoError(t, err)
+	_, err = txn.Exec(`ALTER TENANT $1 PAUSE REPLICATION`, args.DestTenantName)
+	require.NoError(t, err)
+
+	// A concurrent resume has to wait for the pause to commit.
+	resumeDone := make(chan error, 1)
+	go func() {
+		_, err := c.DestSysServer.SQLConn(t).Exec(`ALTER TENANT $1 RESUME REPLICATION`, args.DestTenantName)
+		resumeDone <- err
+	}()
+	select {
+	case err := <-resumeDone:
+		t.Fatalf("resume completed while the tenant record was locked: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
 
-	// First cutover to a future time.
-	var cutoverStr string
-	cutoverTime := replicatedTimeTarget.Add(time.Hour.Nanoseconds(), 0)
-	c.DestSysSQL.QueryRow(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
-		args.DestTenantName, cutoverTime.AsOfSystemTime()).Scan(&cutoverStr)
-	cutoverOutput := replicationtestutils.DecimalTimeToHLC(t, cutoverStr)
-	require.Equal(t, cutoverTime, cutoverOutput)
-	require.Equal(c.T, "replication pending cutover", getTenantStatus())
-	require.Equal(t, cutoverOutput, getCutoverTime())
+	require.NoError(t, txn.Commit())
+	require.NoError(t, <-resumeDone)
 
-	// And cutover to an even further time.
-	cutoverTime = replicatedTimeTarget.Add((time.Hour * 2).Nanoseconds(), 0)
-	c.DestSysSQL.QueryRow(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
-		args.DestTenantName, cutoverTime.AsOfSystemTime()).Scan(&cutoverStr)
-	cutoverOutput = replicationtestutils.DecimalTimeToHLC(t, cutoverStr)
-	require.Equal(t, cutoverTime, cutoverOutput)
-	require.Equal(c.T, "replication pending cutover", getTenantStatus())
-	require.Equal(t, cutoverOutput, getCutoverTime())
+	// The resume was applied after the pause.
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
 }
 
-// TestAlterTenantFailUpdatingCutoverTime verifies that once a cutover has
-// started the cutover time cannot be updated.
-func TestAlterTenantFailUpdatingCutoverTime(t *testing.T) {
+// TestAlterTenantRefreshReplicationStatus verifies that REFRESH REPLICATION
+// STATUS sets the refresh sentinel on a running replication job, which the job
+// then clears once it has persisted its progress, and that it is a no-op if
+// the job is not running.
+func TestAlterTenantRefreshReplicationStatus(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 
-	ctx, ctxCancel := context.WithCancel(context.Background())
+	ctx := context.Background()
 	args := replicationtestutils.DefaultTenantStreamingClustersArgs
-	cutoverCh := make(chan struct{})
-	cutoverStartedCh := make(chan struct{})
-	// Set a knob to hang after we transition to the cutover state, and before the
-	// job finishes.
-	args.TestingKnobs = &sql.StreamingTestingKnobs{
-		AfterCutoverStarted: func() {
-			close(cutoverStartedCh)
-			select {
-			case <-cutoverCh:
-			case <-ctx.Done():
-			}
-		},
-	}
-	unblockJob := func() {
-		select {
-		case cutoverCh <- struct{}{}:
-		case <-ctx.Done():
-		}
-	}
-
 	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
 	defer cleanup()
-	defer ctxCancel()
-
-	getTenantStatus := func() string {
-		var status string
-		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT data_state FROM [SHOW TENANT %s]",
-			c.Args.DestTenantName)).Scan(&status)
-		return status
-	}
 
 	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
-
 	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
 	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
-
-	require.Equal(c.T, "replicating", getTenantStatus())
 
-	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO LATEST`, args.DestTenantName)
-
-	// Wait for cutover to start.
-	<-cutoverStartedCh
+	refreshRequested := func() bool {
+		return jobutils.GetJobProgress(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).
+			GetStreamIngest().RefreshRequested
+	}
+	setPollInterval := func(interval string) {
+		for _, setting := range []string{
+			"physical_replication.consumer.job_checkpoint_frequency",
+			"physical_replication.consumer.cutover_signal_poll_interval",
+		} {
+			c.DestSysSQL.Exec(t, fmt.Sprintf("SET CLUSTER SETTING %s = '%s'", setting, interval))
+		}
+	}
 
-	// Another cutover should fail, verify the error.
-	c.DestSysSQL.ExpectErr(t, "already started cutting over to timestamp",
-		fmt.Sprintf("ALTER TENANT %s COMPLETE REPLICATION TO LATEST", args.DestTenantName))
+	// Stop the job from checking for the sentinel, so that we can observe it.
+	setPollInterval("1h")
+	c.DestSysSQL.CheckQueryResultsRetry(t,
+		"SHOW CLUSTER SETTING physical_replication.consumer.cutover_signal_poll_interval",
+		[][]string{{"01:00:00"}})
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 REFRESH REPLICATION STATUS`, args.DestTenantName)
+	require.True(t, refreshRequested())
 
-	// Done, the tenant is cutting over, unblock the job.
-	unblockJob()
-	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-}
+	// Once the job checks for the sentinel again, it clears it.
+	setPollInterval("100ms")
+	testutils.SucceedsSoon(t, func() error {
+		if refreshRequested() {
+			return errors.New("refresh still requested")
+		}
+		return nil
+	})
 
-// blockingResumer hangs until signaled, before and after running the real
-// resumer.
-type blockingResumer struct {
-	orig       jobs.Resumer
-	waitBefore chan struct{}
-	waitAfter  chan struct{}
-	ctx        context.Context
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 PAUSE REPLICATION`, args.DestTenantName)
+	jobutils.WaitForJobToPause(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 REFRESH REPLICATION STATUS`, args.DestTenantName)
+	require.False(t, refreshRequested())
 }
 
-var _ jobs.Resumer = (*blockingResumer)(nil)
+// TestAlterTenantShowLastRevert verifies that SHOW LAST REVERT returns the
+// time to which the tenant's data was last reverted, and NULL if it never was.
+func TestAlterTenantShowLastRevert(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
 
-func (br *blockingResumer) Resume(ctx context.Context, execCtx interface{}) error {
-	select {
-	case <-br.ctx.Done():
-	case <-br.waitBefore:
-	}
-	r := br.orig.Resume(ctx, execCtx)
-	select {
-	case <-br.ctx.Done():
-	case <-br.waitAfter:
-	}
-	return r
-}
+	ctx := context.Background()
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestControlsTenantsExplicitly,
+	})
+	defer srv.Stopper().Stop(ctx)
 
-func (br *blockingResumer) OnFailOrCancel(context.Context, interface{}, error) error {
-	panic("unimplemented")
-}
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "CREATE TENANT t1")
+	db.CheckQueryResults(t, "ALTER TENANT t1 SHOW LAST REVERT", [][]string{{"NULL"}})
 
-func (br *blockingResumer) CollectProfile(context.Context, interface{}) error {
-	panic("unimplemented")
+	var revertTo string
+	db.QueryRow(t, "SELECT cluster_logical_timestamp()").Scan(&revertTo)
+	db.Exec(t, "ALTER TENANT t1 RESET DATA TO SYSTEM TIME $1::decimal", revertTo)
+	db.CheckQueryResults(t, "ALTER TENANT t1 SHOW LAST REVERT", [][]string{{revertTo}})
 }
 
-// TestTenantStatusWithFutureCutoverTime verifies we go through the tenants
-// states, including the state that the tenant is waiting for a future cutover.
-func TestTenantStatusWithFutureCutoverTime(t *testing.T) {
+// TestAlterTenantReplicationSourceTenant verifies that SET REPLICATION SOURCE
+// TENANT only updates the source tenant name stored in the replication job if
+// a tenant with that name exists on the source.
+func TestAlterTenantReplicationSourceTenant(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 
-	ctx, ctxCancel := context.WithCancel(context.Background())
+	ctx := context.Background()
 	args := replicationtestutils.DefaultTenantStreamingClustersArgs
-
 	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
 	defer cleanup()
 
-	waitBeforeCh := make(chan struct{})
-	waitAfterCh := make(chan struct{})
-	registry := c.DestSysServer.JobRegistry().(*jobs.Registry)
-	registry.TestingWrapResumerConstructor(jobspb.TypeReplicationStreamIngestion,
-		func(raw jobs.Resumer) jobs.Resumer {
-			r := blockingResumer{
-				orig:       raw,
-				waitBefore: waitBeforeCh,
-				waitAfter:  waitAfterCh,
-				ctx:        ctx,
-			}
-			return &r
-		})
-	defer ctxCancel()
-	unblockResumerStart := func() {
-		waitBeforeCh <- struct{}{}
-	}
-	unblockResumerExit := func() {
-		waitAfterCh <- struct{}{}
-	}
-
-	getTenantStatus := func() string {
-		var status string
-		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT data_state FROM [SHOW TENANT %s]",
-			c.Args.DestTenantName)).Scan(&status)
-		return status
-	}
-
 	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
-
-	// The resumer cannot start at this point, therefore the tenant will stay in
-	// the init state.
-	require.Equal(c.T, "initializing replication", getTenantStatus())
-	unblockResumerStart()
-
 	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
 	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
-
-	require.Equal(c.T, "replicating", getTenantStatus())
 
 	c.DestSysSQL.Exec(t, `ALTER TENANT $1 PAUSE REPLICATION`, args.DestTenantName)
-	jobutils.WaitForJobToPause(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-
-	require.Equal(c.T, "replication paused", getTenantStatus())
-
-	// On pause the resumer exits, we should unblock it.
-	unblockResumerExit()
-	c.DestSysSQL.Exec(t, `ALTER TENANT $1 RESUME REPLICATION`, args.DestTenantName)
-	unblockResumerStart()
-	jobutils.WaitForJobToRun(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+	jobutils.WaitForJobToPause(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
 
-	require.Equal(c.T, "replicating", getTenantStatus())
+	getSourceTenant := func() roachpb.TenantName {
+		return jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).
+			GetStreamIngestion().SourceTenantName
+	}
+	require.Equal(t, args.SrcTenantName, getSourceTenant())
 
-	// Cutover to a time far in the future, to make sure we see the pending-cutover state.
-	var cutoverTime time.Time
-	c.DestSysSQL.QueryRow(t, "SELECT clock_timestamp()").Scan(&cutoverTime)
-	cutoverTime = cutoverTime.Add(time.Hour * 24)
-	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
-		args.DestTenantName, cutoverTime)
+	c.DestSysSQL.ExpectErr(t, `validating source tenant "renamed-source"`,
+		`ALTER TENANT $1 SET REPLICATION SOURCE TENANT 'renamed-source'`, args.DestTenantName)
+	c.DestSysSQL.ExpectErr(t, "invalid tenant name",
+		`ALTER TENANT $1 SET REPLICATION SOURCE TENANT 'Not_A_Valid_Name'`, args.DestTenantName)
+	require.Equal(t, args.SrcTenantName, getSourceTenant())
 
-	require.Equal(c.T, "replication pending cutover", getTenantStatus())
-	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO LATEST`, args.DestTenantName)
-	unblockResumerExit()
-	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-	require.Equal(c.T, "ready", getTenantStatus())
+	c.SrcSysSQL.Exec(t, `CREATE VIRTUAL CLUSTER "renamed-source"`)
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION SOURCE TENANT 'renamed-source'`, args.DestTenantName)
+	require.Equal(t, roachpb.TenantName("renamed-source"), getSourceTenant())
 }
 
-// TestTenantStatusWithLatestCutoverTime verifies we go through the actual
-// cutting-over state, which was not verified in the test above because we
-// cannot (currently) alter the cutover time.
-func TestTenantStatusWithLatestCutoverTime(t *testing.T) {
+// TestAlterTenantAdoptReplicationJob verifies that ADOPT REPLICATION JOB
+// transfers a paused replication job from the tenant it replicates into to an
+// inactive replication target.
+func TestAlterTenantAdoptReplicationJob(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 
-	ctx, ctxCancel := context.WithCancel(context.Background())
+	ctx := context.Background()
 	args := replicationtestutils.DefaultTenantStreamingClustersArgs
-	cutoverCh := make(chan struct{})
-	// Set a knob to hang after we transition to the cutover state, and before the
-	// job finishes.
-	args.TestingKnobs = &sql.StreamingTestingKnobs{
-		AfterCutoverStarted: func() { <-cutoverCh },
-	}
-
 	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
 	defer cleanup()
 
-	waitBeforeCh := make(chan struct{})
-	waitAfterCh := make(chan struct{})
-	registry := c.DestSysServer.JobRegistry().(*jobs.Registry)
-	registry.TestingWrapResumerConstructor(jobspb.TypeReplicationStreamIngestion,
-		func(raw jobs.Resumer) jobs.Resumer {
-			r := blockingResumer{
-				orig:       raw,
-				waitBefore: waitBeforeCh,
-				waitAfter:  waitAfterCh,
-				ctx:        ctx,
-			}
-			return &r
-		})
-	defer ctxCancel()
-	unblockResumerStart := func() {
-		waitBeforeCh <- struct{}{}
-	}
-	unblockResumerExit := func() {
-		waitAfterCh <- struct{}{}
-	}
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
 
-	getTenantStatus := func() string {
-		var status string
-		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT data_state FROM [SHOW TENANT %s]",
-			c.Args.DestTenantName)).Scan(&status)
-		return status
+	execCfg := c.DestSysServer.ExecutorConfig().(sql.ExecutorConfig)
+	getTenantInfo := func(name roachpb.TenantName) *mtinfopb.TenantInfo {
+		var tenInfo *mtinfopb.TenantInfo
+		require.NoError(t, execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			var err error
+			tenInfo, err = sql.GetTenantRecordByName(ctx, execCfg.Settings, txn, name)
+			return err
+		}))
+		return tenInfo
 	}
 
-	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+	// Set up an inactive replication target by canceling the job replicating
+	// into it, which leaves it in the ADD data state.
+	const adopter = roachpb.TenantName("adopter")
+	c.DestSysSQL.Exec(t, fmt.Sprintf("CREATE TENANT %s FROM REPLICATION OF %s ON '%s'",
+		adopter, args.SrcTenantName, c.SrcURL.String()))
+	adopterJobID := getTenantInfo(adopter).PhysicalReplicationConsumerJobID
+	c.DestSysSQL.Exec(t, "CANCEL JOB $1", adopterJobID)
+	jobutils.WaitForJobToCancel(t, c.DestSysSQL, adopterJobID)
+
+	c.DestSysSQL.ExpectErr(t, "must be paused to be adopted",
+		"ALTER TENANT $1 ADOPT REPLICATION JOB $2", adopter, ingestionJobID)
+
+	c.DestSysSQL.Exec(t, "ALTER TENANT $1 PAUSE REPLICATION", args.DestTenantName)
+	jobutils.WaitForJobToPause(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.DestSysSQL.Exec(t, "CREATE TENANT ready")
+	c.DestSysSQL.ExpectErr(t, "must be in data state",
+		"ALTER TENANT ready ADOPT REPLICATION JOB $1", ingestionJobID)
+
+	c.DestSysSQL.Exec(t, "ALTER TENANT $1 ADOPT REPLICATION JOB $2", adopter, ingestionJobID)
+
+	adopterInfo := getTenantInfo(adopter)
+	require.Equal(t, jobspb.JobID(ingestionJobID), adopterInfo.PhysicalReplicationConsumerJobID)
+	require.Zero(t, getTenantInfo(args.DestTenantName).PhysicalReplicationConsumerJobID)
+
+	adopterID := roachpb.MustMakeTenantID(adopterInfo.ID)
+	details := jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion()
+	require.Equal(t, adopterID, details.DestinationTenantID)
+	require.Equal(t, keys.MakeTenantSpan(adopterID), details.Span)
+	require.NotNil(t, details.ProtectedTimestampRecordID)
+	require.NoError(t, execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		record, err := execCfg.ProtectedTimestampProvider.WithTxn(txn).GetRecord(
+			ctx, *details.ProtectedTimestampRecordID)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, []roachpb.TenantID{adopterID}, record.Target.GetTenants().IDs)
+		return nil
+	}))
+}
 
-	require.Equal(c.T, "initializing replication", getTenantStatus())
-	unblockResumerStart()
+// TestAlterTenantReplicationTarget verifies that SET REPLICATION TARGET TENANT
+// re-targets a paused replication job at another replication target, updating
+// the job's destination tenant and span along with both tenant records.
+func TestAlterTenantReplicationTarget(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
 
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
 	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
 	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
 
-	require.Equal(c.T, "replicating", getTenantStatus())
+	execCfg := c.DestSysServer.ExecutorConfig().(sql.ExecutorConfig)
+	getTenantInfo := func(name roachpb.TenantName) *mtinfopb.TenantInfo {
+		var tenInfo *mtinfopb.TenantInfo
+		require.NoError(t, execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			var err error
+			tenInfo, err = sql.GetTenantRecordByName(ctx, execCfg.Settings, txn, name)
+			return err
+		}))
+		return tenInfo
+	}
 
-	c.DestSysSQL.Exec(c.T, fmt.Sprintf("ALTER TENANT %s COMPLETE REPLICATION TO LATEST", args.DestTenantName))
+	// Set up an inactive replication target by canceling the job replicating
+	// into it, which leaves it in the ADD data state.
+	const renumbered = roachpb.TenantName("renumbered")
+	c.DestSysSQL.Exec(t, fmt.Sprintf("CREATE TENANT %s FROM REPLICATION OF %s ON '%s'",
+		renumbered, args.SrcTenantName, c.SrcURL.String()))
+	renumberedJobID := getTenantInfo(renumbered).PhysicalReplicationConsumerJobID
+	c.DestSysSQL.Exec(t, "CANCEL JOB $1", renumberedJobID)
+	jobutils.WaitForJobToCancel(t, c.DestSysSQL, renumberedJobID)
+	renumberedID := roachpb.MustMakeTenantID(getTenantInfo(renumbered).ID)
+
+	c.DestSysSQL.ExpectErr(t, "must be paused to be re-targeted",
+		"ALTER TENANT $1 SET REPLICATION TARGET TENANT $2", args.DestTenantName, renumberedID.ToUint64())
+
+	c.DestSysSQL.Exec(t, "ALTER TENANT $1 PAUSE REPLICATION", args.DestTenantName)
+	jobutils.WaitForJobToPause(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.DestSysSQL.ExpectErr(t, "does not exist",
+		"ALTER TENANT $1 SET REPLICATION TARGET TENANT 1000", args.DestTenantName)
+	c.DestSysSQL.Exec(t, "CREATE TENANT ready")
+	c.DestSysSQL.ExpectErr(t, "must be in data state",
+		"ALTER TENANT $1 SET REPLICATION TARGET TENANT $2", args.DestTenantName, getTenantInfo("ready").ID)
+
+	c.DestSysSQL.Exec(t, "ALTER TENANT $1 SET REPLICATION TARGET TENANT $2",
+		args.DestTenantName, renumberedID.ToUint64())
+
+	require.Equal(t, jobspb.JobID(ingestionJobID), getTenantInfo(renumbered).PhysicalReplicationConsumerJobID)
+	require.Zero(t, getTenantInfo(args.DestTenantName).PhysicalReplicationConsumerJobID)
+	details := jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion()
+	require.Equal(t, renumberedID, details.DestinationTenantID)
+	require.Equal(t, keys.MakeTenantSpan(renumberedID), details.Span)
+}
 
-	testutils.SucceedsSoon(t, func() error {
-		s := getTenantStatus()
-		if s == "replication pending cutover" {
-			return errors.Errorf("tenant status is still 'replication pending cutover', waiting")
-		}
-		require.Equal(c.T, "replication cutting over", s)
-		return nil
+// TestAlterTenantClearReplicationJobReference verifies that a tenant's
+// reference to a replication job that no longer exists can be cleared, and that
+// a reference to a job that does exist cannot.
+func TestAlterTenantClearReplicationJobReference(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestControlsTenantsExplicitly,
 	})
+	defer srv.Stopper().Stop(ctx)
 
-	// Done, the tenant is cutting over, unblock the job.
-	cutoverCh <- struct{}{}
-	unblockResumerExit()
-	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-	require.Equal(c.T, "ready", getTenantStatus())
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "CREATE TENANT t1")
+
+	execCfg := srv.ExecutorConfig().(sql.ExecutorConfig)
+	setJobReference := func(jobID jobspb.JobID) {
+		require.NoError(t, execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			tenInfo, err := sql.GetTenantRecordByName(ctx, execCfg.Settings, txn, "t1")
+			if err != nil {
+				return err
+			}
+			tenInfo.PhysicalReplicationConsumerJobID = jobID
+			return sql.UpdateTenantRecord(ctx, execCfg.Settings, txn, tenInfo)
+		}))
+	}
+	getJobReference := func() jobspb.JobID {
+		var jobID jobspb.JobID
+		require.NoError(t, execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			tenInfo, err := sql.GetTenantRecordByName(ctx, execCfg.Settings, txn, "t1")
+			if err != nil {
+				return err
+			}
+			jobID = tenInfo.PhysicalReplicationConsumerJobID
+			return nil
+		}))
+		return jobID
+	}
+
+	// A reference to a job that exists is refused, whatever the job is.
+	var existingJobID jobspb.JobID
+	db.QueryRow(t, "SELECT id FROM system.jobs LIMIT 1").Scan(&existingJobID)
+	setJobReference(existingJobID)
+	db.ExpectErr(t, "the job still exists",
+		"ALTER TENANT t1 CLEAR REPLICATION JOB REFERENCE")
+	require.Equal(t, existingJobID, getJobReference())
+
+	// A dangling reference fails other statements, and can be cleared.
+	const droppedJobID = jobspb.JobID(123456789)
+	setJobReference(droppedJobID)
+	db.ExpectErr(t, "job with ID 123456789 does not exist",
+		"ALTER TENANT t1 PAUSE REPLICATION")
+	db.Exec(t, "ALTER TENANT t1 CLEAR REPLICATION JOB REFERENCE")
+	require.Zero(t, getJobReference())
+
+	// Clearing a tenant without a reference is a no-op.
+	db.Exec(t, "ALTER TENANT t1 CLEAR REPLICATION JOB REFERENCE")
 }
 
-// TestTenantReplicationStatus verifies replication status errors.
-func TestTenantReplicationStatus(t *testing.T) {
+// TestAlterTenantReplicationOptionMetrics verifies that altering the options
+// of a replication job increments the metric of each option that changed.
+func TestAlterTenantReplicationOptionMetrics(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 
@@ -492,111 +738,2566 @@ func TestTenantReplicationStatus(t *testing.T) {
 
 	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
 	defer cleanup()
-
 	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
 
 	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
 	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
 
 	registry := c.DestSysServer.JobRegistry().(*jobs.Registry)
+	metrics := registry.MetricsStruct().StreamIngest.(*Metrics)
+	optionChanges := func(option string) int64 {
+		metrics.optionChanges.Lock()
+		defer metrics.optionChanges.Unlock()
+		if c, ok := metrics.optionChanges.counters[option]; ok {
+			return c.Value()
+		}
+		return 0
+	}
+	require.Equal(t, int64(0), metrics.OptionChanges.Count())
 
-	// Pass a nonexistent job id.
-	_, status, err := getReplicationStatsAndStatus(ctx, registry, nil, jobspb.JobID(-1))
-	require.ErrorContains(t, err, "job with ID -1 does not exist")
-	require.Equal(t, "replication error", status)
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION RETENTION = '42s'`, args.DestTenantName)
+	require.Equal(t, int64(1), optionChanges("RETENTION"))
+	require.Equal(t, int64(0), optionChanges("PRIORITY"))
 
-	// The producer job in the source cluster should not have a replication status.
-	registry = c.SrcSysServer.JobRegistry().(*jobs.Registry)
-	_, status, err = getReplicationStatsAndStatus(ctx, registry, nil, jobspb.JobID(producerJobID))
-	require.ErrorContains(t, err, "is not a stream ingestion job")
-	require.Equal(t, "replication error", status)
+	// Setting the retention to its current value is not a change.
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION RETENTION = '42s'`, args.DestTenantName)
+	require.Equal(t, int64(1), optionChanges("RETENTION"))
+
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION RETENTION = '1h'`, args.DestTenantName)
+	require.Equal(t, int64(2), optionChanges("RETENTION"))
+
+	// Each option altered by a statement is counted separately.
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION OWNER = 'dr-team', MAX_PARTITION_STREAMS = 4`,
+		args.DestTenantName)
+	require.Equal(t, int64(1), optionChanges("OWNER"))
+	require.Equal(t, int64(1), optionChanges("MAX_PARTITION_STREAMS"))
+	require.Equal(t, int64(2), optionChanges("RETENTION"))
+	require.Equal(t, int64(4), metrics.OptionChanges.Count())
 }
 
-// TestAlterTenantHandleFutureProtectedTimestamp verifies that cutting over "TO
-// LATEST" doesn't fail if the destination cluster clock is ahead of the source
-// cluster clock. See issue #96477.
-func TestAlterTenantHandleFutureProtectedTimestamp(t *testing.T) {
+// TestAlterTenantReplicationOwner verifies that the OWNER option is persisted
+// in the replication job and can be altered.
+func TestAlterTenantReplicationOwner(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 
 	ctx := context.Background()
 	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	args.RetentionTTLSeconds = 60 * 60
+
 	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
 	defer cleanup()
 
-	// Push the clock on the destination cluster forward.
-	destNow := c.DestCluster.Server(0).Clock().NowAsClockTimestamp()
-	destNow.WallTime += (200 * time.Millisecond).Nanoseconds()
-	c.DestCluster.Server(0).Clock().Update(destNow)
+	c.DestSysSQL.Exec(t, c.BuildCreateTenantQuery("")+", OWNER = 'team-dr'")
+	_, ingestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, args.DestTenantName)
 
-	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+	getOwner := func() string {
+		return jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion().Owner
+	}
+	require.Equal(t, "team-dr", getOwner())
 
-	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
-	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
-	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION OWNER = 'team-infra'`, args.DestTenantName)
+	require.Equal(t, "team-infra", getOwner())
 
-	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO LATEST`, args.DestTenantName)
+	c.DestSysSQL.ExpectErr(t, "invalid OWNER: cannot be empty",
+		`ALTER TENANT $1 SET REPLICATION OWNER = ''`, args.DestTenantName)
+	require.Equal(t, "team-infra", getOwner())
 }
 
-func TestAlterTenantStartReplicationAfterRestore(t *testing.T) {
+// TestAlterTenantPauseOnDiskFull verifies that the PAUSE_ON_DISK_FULL option is
+// persisted in the ingestion job details, and that out-of-range thresholds are
+// rejected without modifying them.
+func TestAlterTenantPauseOnDiskFull(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 
 	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	args.RetentionTTLSeconds = 60 * 60
 
-	var enforcedGC struct {
-		syncutil.Mutex
-		ts hlc.Timestamp
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	c.DestSysSQL.Exec(t, c.BuildCreateTenantQuery("")+", PAUSE_ON_DISK_FULL = 90")
+	_, ingestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, args.DestTenantName)
+
+	getThreshold := func() int32 {
+		return jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion().PauseOnDiskFull
 	}
+	require.Equal(t, int32(90), getThreshold())
 
-	testingRequestFilter := func(_ context.Context, ba *kvpb.BatchRequest) *kvpb.Error {
-		for _, req := range ba.Requests {
-			if revReq := req.GetRevertRange(); revReq != nil {
-				enforcedGC.Lock()
-				defer enforcedGC.Unlock()
-				if enforcedGC.ts.IsSet() && revReq.TargetTime.Less(enforcedGC.ts) {
-					return kvpb.NewError(&kvpb.BatchTimestampBeforeGCError{
-						Timestamp: revReq.TargetTime,
-						Threshold: enforcedGC.ts,
-					})
-				}
-			}
-		}
-		return nil
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION PAUSE_ON_DISK_FULL = 75`, args.DestTenantName)
+	require.Equal(t, int32(75), getThreshold())
+
+	c.DestSysSQL.ExpectErr(t, "invalid PAUSE_ON_DISK_FULL -5",
+		`ALTER TENANT $1 SET REPLICATION PAUSE_ON_DISK_FULL = -5`, args.DestTenantName)
+	c.DestSysSQL.ExpectErr(t, "invalid PAUSE_ON_DISK_FULL 101",
+		`ALTER TENANT $1 SET REPLICATION PAUSE_ON_DISK_FULL = 101`, args.DestTenantName)
+	require.Equal(t, int32(75), getThreshold())
+}
+
+// TestAlterTenantMaxPartitionStreams verifies that the MAX_PARTITION_STREAMS
+// option is persisted in the ingestion job details, and that non-positive
+// limits are rejected without modifying it.
+func TestAlterTenantMaxPartitionStreams(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	args.RetentionTTLSeconds = 60 * 60
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	c.DestSysSQL.Exec(t, c.BuildCreateTenantQuery("")+", MAX_PARTITION_STREAMS = 4")
+	_, ingestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, args.DestTenantName)
+
+	getLimit := func() int32 {
+		return jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion().MaxPartitionStreams
 	}
+	require.Equal(t, int32(4), getLimit())
 
-	nodelocalCleanup := nodelocal.ReplaceNodeLocalForTesting(t.TempDir())
-	defer nodelocalCleanup()
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION MAX_PARTITION_STREAMS = 2`, args.DestTenantName)
+	require.Equal(t, int32(2), getLimit())
 
-	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
-		DefaultTestTenant: base.TestControlsTenantsExplicitly,
-		Knobs: base.TestingKnobs{
-			Store: &kvserver.StoreTestingKnobs{
-				TestingRequestFilter: testingRequestFilter,
-			},
-		},
-	})
-	defer srv.Stopper().Stop(ctx)
+	c.DestSysSQL.ExpectErr(t, "invalid MAX_PARTITION_STREAMS 0",
+		`ALTER TENANT $1 SET REPLICATION MAX_PARTITION_STREAMS = 0`, args.DestTenantName)
+	require.Equal(t, int32(2), getLimit())
+}
 
-	db := sqlutils.MakeSQLRunner(sqlDB)
+// TestAlterTenantVerifyChecksums verifies that the VERIFY_CHECKSUMS option is
+// persisted in the ingestion job details, and can be altered afterwards.
+func TestAlterTenantVerifyChecksums(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
 
-	db.Exec(t, "CREATE TENANT t1")
-	db.Exec(t, "BACKUP TENANT 3 TO 'nodelocal://1/t'")
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	args.RetentionTTLSeconds = 60 * 60
 
-	afterBackup := srv.Clock().Now()
-	enforcedGC.Lock()
-	enforcedGC.ts = afterBackup
-	enforcedGC.Unlock()
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
 
-	u, cleanupURLA := sqlutils.PGUrl(t, srv.SQLAddr(), t.Name(), url.User(username.RootUser))
-	defer cleanupURLA()
+	c.DestSysSQL.Exec(t, c.BuildCreateTenantQuery("")+", VERIFY_CHECKSUMS = true")
+	_, ingestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, args.DestTenantName)
 
-	db.Exec(t, "RESTORE TENANT 3 FROM 'nodelocal://1/t' WITH TENANT = '5', TENANT_NAME = 't2'")
-	db.Exec(t, "ALTER TENANT t2 START REPLICATION OF t1 ON $1", u.String())
-	srv.JobRegistry().(*jobs.Registry).TestingNudgeAdoptionQueue()
+	getVerifyChecksums := func() bool {
+		return jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion().VerifyChecksums
+	}
+	require.True(t, getVerifyChecksums())
 
-	_, ingestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, db, "t2")
-	srcTime := srv.Clock().Now()
-	replicationtestutils.WaitUntilReplicatedTime(t, srcTime, db, catpb.JobID(ingestionJobID))
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION VERIFY_CHECKSUMS = false`, args.DestTenantName)
+	require.False(t, getVerifyChecksums())
+}
+
+// TestAlterTenantInitialRetention verifies that the INITIAL_RETENTION option is
+// persisted alongside RETENTION in the ingestion job details, and that it can
+// never be set shorter than the steady state retention.
+func TestAlterTenantInitialRetention(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	args.RetentionTTLSeconds = 24 * 60 * 60
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	c.DestSysSQL.Exec(t, c.BuildCreateTenantQuery("")+", INITIAL_RETENTION = '72h'")
+	_, ingestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, c.DestSysSQL, args.DestTenantName)
+
+	getRetentions := func() (initial, steady int32) {
+		details := jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion()
+		return details.InitialReplicationTTLSeconds, details.ReplicationTTLSeconds
+	}
+	initial, steady := getRetentions()
+	require.Equal(t, int32(72*60*60), initial)
+	require.Equal(t, int32(24*60*60), steady)
+
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION INITIAL_RETENTION = '48h'`, args.DestTenantName)
+	initial, steady = getRetentions()
+	require.Equal(t, int32(48*60*60), initial)
+	require.Equal(t, int32(24*60*60), steady)
+
+	// Neither retention may be changed such that the initial one becomes the
+	// shorter of the two.
+	c.DestSysSQL.ExpectErr(t, "INITIAL_RETENTION \\(12h0m0s\\) must not be shorter than RETENTION \\(24h0m0s\\)",
+		`ALTER TENANT $1 SET REPLICATION INITIAL_RETENTION = '12h'`, args.DestTenantName)
+	c.DestSysSQL.ExpectErr(t, "must not be shorter than RETENTION",
+		`ALTER TENANT $1 SET REPLICATION RETENTION = '72h'`, args.DestTenantName)
+	initial, steady = getRetentions()
+	require.Equal(t, int32(48*60*60), initial)
+	require.Equal(t, int32(24*60*60), steady)
+}
+
+// TestAlterTenantRetentionFromZone verifies that RETENTION = FROM ZONE sets the
+// replication retention to the GC TTL of the named zone.
+func TestAlterTenantRetentionFromZone(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	_, ingestionJobID := c.StartStreamReplication(ctx)
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	c.DestSysSQL.Exec(t, `ALTER RANGE default CONFIGURE ZONE USING gc.ttlseconds = 7200`)
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION RETENTION = FROM ZONE 'default'`, args.DestTenantName)
+	details := jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion()
+	require.Equal(t, int32(7200), details.ReplicationTTLSeconds)
+
+	c.DestSysSQL.ExpectErr(t, `unknown zone "nonexistent"`,
+		`ALTER TENANT $1 SET REPLICATION RETENTION = FROM ZONE 'nonexistent'`, args.DestTenantName)
+}
+
+// TestAlterTenantOptionsDuringCutover verifies that the options of a
+// replication job cannot be altered once a cutover has been requested.
+func TestAlterTenantOptionsDuringCutover(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION RETENTION = '42s'`, args.DestTenantName)
+
+	// Cutover to a time far in the future so that the job remains pending
+	// cutover.
+	var cutoverTime time.Time
+	c.DestSysSQL.QueryRow(t, "SELECT clock_timestamp()").Scan(&cutoverTime)
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, cutoverTime.Add(24*time.Hour))
+
+	c.DestSysSQL.ExpectErr(t, "is finishing its cutover",
+		`ALTER TENANT $1 SET REPLICATION RETENTION = '1h'`, args.DestTenantName)
+
+	details := jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion()
+	require.Equal(t, int32(42), details.ReplicationTTLSeconds)
+}
+
+// TestAlterTenantClearAutoCutover verifies that a scheduled AUTO_CUTOVER
+// prevents completing replication manually until it is cleared by setting it to
+// NULL, after which the virtual cluster can be cut over.
+func TestAlterTenantClearAutoCutover(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	autoCutoverTime := func() hlc.Timestamp {
+		details := jobutils.GetJobPayload(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngestion()
+		return details.AutoCutoverTime
+	}
+
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION AUTO_CUTOVER = '2100-01-01 00:00:00'`,
+		args.DestTenantName)
+	require.False(t, autoCutoverTime().IsEmpty())
+
+	replicatedTimeTarget := c.SrcCluster.Server(0).Clock().Now()
+	c.WaitUntilReplicatedTime(replicatedTimeTarget, jobspb.JobID(ingestionJobID))
+	c.DestSysSQL.ExpectErr(t, "it is scheduled to cut over automatically",
+		`ALTER TENANT $1 COMPLETE REPLICATION TO LATEST`, args.DestTenantName)
+
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 SET REPLICATION AUTO_CUTOVER = NULL`, args.DestTenantName)
+	require.True(t, autoCutoverTime().IsEmpty())
+
+	var cutoverStr string
+	c.DestSysSQL.QueryRow(t, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, replicatedTimeTarget.AsOfSystemTime()).Scan(&cutoverStr)
+	require.Equal(t, replicatedTimeTarget, replicationtestutils.DecimalTimeToHLC(t, cutoverStr))
+	jobutils.WaitForJobToSucceed(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+}
+
+// TestAlterTenantUpdateExistingCutoverTime verifies we can set a new cutover
+// time if the cutover process did not start yet.
+func TestAlterTenantUpdateExistingCutoverTime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	getTenantStatus := func() string {
+		var status string
+		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT data_state FROM [SHOW TENANT %s]",
+			c.Args.DestTenantName)).Scan(&status)
+		return status
+	}
+	getCutoverTime := func() hlc.Timestamp {
+		var cutoverStr string
+		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT cutover_time FROM [SHOW TENANT %s WITH REPLICATION STATUS]",
+			c.Args.DestTenantName)).Scan(&cutoverStr)
+		cutoverOutput := replicationtestutils.DecimalTimeToHLC(t, cutoverStr)
+		return cutoverOutput
+	}
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	replicatedTimeTarget := c.SrcCluster.Server(0).Clock().Now()
+	c.WaitUntilReplicatedTime(replicatedTimeTarget, jobspb.JobID(ingestionJobID))
+
+	// First cutover to a future time.
+	var cutoverStr string
+	cutoverTime := replicatedTimeTarget.Add(time.Hour.Nanoseconds(), 0)
+	c.DestSysSQL.QueryRow(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, cutoverTime.AsOfSystemTime()).Scan(&cutoverStr)
+	cutoverOutput := replicationtestutils.DecimalTimeToHLC(t, cutoverStr)
+	require.Equal(t, cutoverTime, cutoverOutput)
+	require.Equal(c.T, "replication pending cutover", getTenantStatus())
+	require.Equal(t, cutoverOutput, getCutoverTime())
+
+	// And cutover to an even further time.
+	cutoverTime = replicatedTimeTarget.Add((time.Hour * 2).Nanoseconds(), 0)
+	c.DestSysSQL.QueryRow(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, cutoverTime.AsOfSystemTime()).Scan(&cutoverStr)
+	cutoverOutput = replicationtestutils.DecimalTimeToHLC(t, cutoverStr)
+	require.Equal(t, cutoverTime, cutoverOutput)
+	require.Equal(c.T, "replication pending cutover", getTenantStatus())
+	require.Equal(t, cutoverOutput, getCutoverTime())
+}
+
+// TestAlterTenantCutoverDrainBeforeCutover verifies that a cutover requested
+// WITH DRAIN_BEFORE_CUTOVER records the request in the job's progress, and that
+// a later cutover request without it clears it.
+func TestAlterTenantCutoverDrainBeforeCutover(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	replicatedTimeTarget := c.SrcCluster.Server(0).Clock().Now()
+	c.WaitUntilReplicatedTime(replicatedTimeTarget, jobspb.JobID(ingestionJobID))
+
+	getProgress := func() *jobspb.StreamIngestionProgress {
+		return jobutils.GetJobProgress(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngest()
+	}
+
+	// Cut over to a future time, so that the cutover remains pending.
+	cutoverTime := replicatedTimeTarget.Add(time.Hour.Nanoseconds(), 0)
+	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string WITH DRAIN_BEFORE_CUTOVER`,
+		args.DestTenantName, cutoverTime.AsOfSystemTime())
+	progress := getProgress()
+	require.Equal(t, cutoverTime, progress.CutoverTime)
+	require.True(t, progress.DrainBeforeCutover)
+
+	cutoverTime = replicatedTimeTarget.Add((time.Hour * 2).Nanoseconds(), 0)
+	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, cutoverTime.AsOfSystemTime())
+	progress = getProgress()
+	require.Equal(t, cutoverTime, progress.CutoverTime)
+	require.False(t, progress.DrainBeforeCutover)
+}
+
+// TestAlterTenantResumeRefreshTopology verifies that resuming a replication
+// job WITH REFRESH_TOPOLOGY flags the job to re-query the partition layout of
+// the source, and that the flag is cleared once the job has planned with the
+// refreshed topology.
+func TestAlterTenantResumeRefreshTopology(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	var blockIngestion atomic.Bool
+	ingestionBlocked := make(chan struct{})
+	unblockIngestion := make(chan struct{})
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	args.TestingKnobs = &sql.StreamingTestingKnobs{
+		BeforeIngestionStart: func(ctx context.Context) error {
+			if blockIngestion.Load() {
+				ingestionBlocked <- struct{}{}
+				<-unblockIngestion
+			}
+			return nil
+		},
+	}
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	getProgress := func() *jobspb.StreamIngestionProgress {
+		return jobutils.GetJobProgress(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngest()
+	}
+
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 PAUSE REPLICATION`, args.DestTenantName)
+	jobutils.WaitForJobToPause(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	require.False(t, getProgress().RefreshTopology)
+
+	// Hold the resumed job before it plans, so that the flag can be observed.
+	blockIngestion.Store(true)
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 RESUME REPLICATION WITH REFRESH_TOPOLOGY`, args.DestTenantName)
+	<-ingestionBlocked
+	require.True(t, getProgress().RefreshTopology)
+
+	blockIngestion.Store(false)
+	close(unblockIngestion)
+	testutils.SucceedsSoon(t, func() error {
+		if getProgress().RefreshTopology {
+			return errors.New("waiting for the refreshed topology to be persisted")
+		}
+		return nil
+	})
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+}
+
+// TestAlterTenantFailUpdatingCutoverTime verifies that once a cutover has
+// started the cutover time cannot be updated.
+func TestAlterTenantFailUpdatingCutoverTime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	cutoverCh := make(chan struct{})
+	cutoverStartedCh := make(chan struct{})
+	// Set a knob to hang after we transition to the cutover state, and before the
+	// job finishes.
+	args.TestingKnobs = &sql.StreamingTestingKnobs{
+		AfterCutoverStarted: func() {
+			close(cutoverStartedCh)
+			select {
+			case <-cutoverCh:
+			case <-ctx.Done():
+			}
+		},
+	}
+	unblockJob := func() {
+		select {
+		case cutoverCh <- struct{}{}:
+		case <-ctx.Done():
+		}
+	}
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+	defer ctxCancel()
+
+	getTenantStatus := func() string {
+		var status string
+		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT data_state FROM [SHOW TENANT %s]",
+			c.Args.DestTenantName)).Scan(&status)
+		return status
+	}
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	require.Equal(c.T, "replicating", getTenantStatus())
+
+	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO LATEST`, args.DestTenantName)
+
+	// Wait for cutover to start.
+	<-cutoverStartedCh
+
+	// Another cutover should fail, verify the error.
+	c.DestSysSQL.ExpectErr(t, "already started cutting over to timestamp",
+		fmt.Sprintf("ALTER TENANT %s COMPLETE REPLICATION TO LATEST", args.DestTenantName))
+
+	// Done, the tenant is cutting over, unblock the job.
+	unblockJob()
+	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+}
+
+// blockingResumer hangs until signaled, before and after running the real
+// resumer.
+type blockingResumer struct {
+	orig       jobs.Resumer
+	waitBefore chan struct{}
+	waitAfter  chan struct{}
+	ctx        context.Context
+}
+
+var _ jobs.Resumer = (*blockingResumer)(nil)
+
+func (br *blockingResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	select {
+	case <-br.ctx.Done():
+	case <-br.waitBefore:
+	}
+	r := br.orig.Resume(ctx, execCtx)
+	select {
+	case <-br.ctx.Done():
+	case <-br.waitAfter:
+	}
+	return r
+}
+
+func (br *blockingResumer) OnFailOrCancel(context.Context, interface{}, error) error {
+	panic("unimplemented")
+}
+
+func (br *blockingResumer) CollectProfile(context.Context, interface{}) error {
+	panic("unimplemented")
+}
+
+// advancingPTSStorage wraps a protectedts.Storage and reports an advanced
+// timestamp on every GetRecord call after the first, simulating the protected
+// timestamp record being advanced concurrently with a cutover.
+type advancingPTSStorage struct {
+	protectedts.Storage
+	reads      int
+	advancedTo hlc.Timestamp
+}
+
+func (s *advancingPTSStorage) GetRecord(
+	ctx context.Context, id uuid.UUID,
+) (*ptpb.Record, error) {
+	record, err := s.Storage.GetRecord(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.reads++
+	if s.reads > 1 {
+		record.Timestamp = s.advancedTo
+	}
+	return record, nil
+}
+
+// TestAlterTenantCutoverRevalidatesProtectedTimestamp verifies that the cutover
+// time is validated again against the protected timestamp record when it is
+// applied, so that a record that advanced after the initial validation is
+// respected.
+func TestAlterTenantCutoverRevalidatesProtectedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	cutoverTime := replicationutils.ReplicatedTimeFromProgress(
+		jobutils.GetJobProgress(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)))
+	require.False(t, cutoverTime.IsEmpty())
+
+	execCfg := c.DestSysServer.ExecutorConfig().(sql.ExecutorConfig)
+	stmt := &tree.AlterTenantReplication{
+		Cutover: &tree.ReplicationCutoverTime{Timestamp: tree.NewStrVal(cutoverTime.AsOfSystemTime())},
+	}
+	err := c.DestSysServer.InternalDB().(isql.DB).Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		tenInfo, err := sql.GetTenantRecordByName(ctx, execCfg.Settings, txn, args.DestTenantName)
+		if err != nil {
+			return err
+		}
+		ptp := &advancingPTSStorage{
+			Storage:    execCfg.ProtectedTimestampProvider.WithTxn(txn),
+			advancedTo: cutoverTime.Next(),
+		}
+		_, err = alterTenantJobCutover(
+			ctx, txn, execCfg.JobRegistry, ptp, &recordingNoticeSender{}, &execCfg.Settings.SV, stmt, tenInfo, cutoverTime, 0 /* clockSkewTolerance */)
+		return err
+	})
+	require.ErrorContains(t, err, "before earliest safe cutover time")
+
+	progress := jobutils.GetJobProgress(t, c.DestSysSQL, jobspb.JobID(ingestionJobID)).GetStreamIngest()
+	require.True(t, progress.CutoverTime.IsEmpty())
+}
+
+// TestAlterTenantJobCutoverNilProtectedTimestamps verifies that a cutover
+// without a protected timestamp provider fails cleanly instead of panicking.
+func TestAlterTenantJobCutoverNilProtectedTimestamps(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	stmt := &tree.AlterTenantReplication{
+		Cutover: &tree.ReplicationCutoverTime{Latest: true},
+	}
+	tenInfo := &mtinfopb.TenantInfo{}
+	_, err := alterTenantJobCutover(
+		context.Background(),
+		nil, /* txn */
+		nil, /* jobRegistry */
+		nil, /* ptp */
+		&recordingNoticeSender{},
+		nil, /* sv */
+		stmt,
+		tenInfo,
+		hlc.Timestamp{},
+		0, /* clockSkewTolerance */
+	)
+	require.ErrorContains(t, err, "protected timestamp subsystem unavailable")
+}
+
+// TestTenantStatusWithFutureCutoverTime verifies we go through the tenants
+// states, including the state that the tenant is waiting for a future cutover.
+func TestTenantStatusWithFutureCutoverTime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	waitBeforeCh := make(chan struct{})
+	waitAfterCh := make(chan struct{})
+	registry := c.DestSysServer.JobRegistry().(*jobs.Registry)
+	registry.TestingWrapResumerConstructor(jobspb.TypeReplicationStreamIngestion,
+		func(raw jobs.Resumer) jobs.Resumer {
+			r := blockingResumer{
+				orig:       raw,
+				waitBefore: waitBeforeCh,
+				waitAfter:  waitAfterCh,
+				ctx:        ctx,
+			}
+			return &r
+		})
+	defer ctxCancel()
+	unblockResumerStart := func() {
+		waitBeforeCh <- struct{}{}
+	}
+	unblockResumerExit := func() {
+		waitAfterCh <- struct{}{}
+	}
+
+	getTenantStatus := func() string {
+		var status string
+		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT data_state FROM [SHOW TENANT %s]",
+			c.Args.DestTenantName)).Scan(&status)
+		return status
+	}
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	// The resumer cannot start at this point, therefore the tenant will stay in
+	// the init state.
+	require.Equal(c.T, "initializing replication", getTenantStatus())
+	unblockResumerStart()
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	require.Equal(c.T, "replicating", getTenantStatus())
+
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 PAUSE REPLICATION`, args.DestTenantName)
+	jobutils.WaitForJobToPause(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+
+	require.Equal(c.T, "replication paused", getTenantStatus())
+
+	// On pause the resumer exits, we should unblock it.
+	unblockResumerExit()
+	c.DestSysSQL.Exec(t, `ALTER TENANT $1 RESUME REPLICATION`, args.DestTenantName)
+	unblockResumerStart()
+	jobutils.WaitForJobToRun(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	require.Equal(c.T, "replicating", getTenantStatus())
+
+	// Cutover to a time far in the future, to make sure we see the pending-cutover state.
+	var cutoverTime time.Time
+	c.DestSysSQL.QueryRow(t, "SELECT clock_timestamp()").Scan(&cutoverTime)
+	cutoverTime = cutoverTime.Add(time.Hour * 24)
+	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO SYSTEM TIME $2::string`,
+		args.DestTenantName, cutoverTime)
+
+	require.Equal(c.T, "replication pending cutover", getTenantStatus())
+	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO LATEST`, args.DestTenantName)
+	unblockResumerExit()
+	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	require.Equal(c.T, "ready", getTenantStatus())
+}
+
+// TestTenantStatusWithLatestCutoverTime verifies we go through the actual
+// cutting-over state, which was not verified in the test above because we
+// cannot (currently) alter the cutover time.
+func TestTenantStatusWithLatestCutoverTime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	cutoverCh := make(chan struct{})
+	// Set a knob to hang after we transition to the cutover state, and before the
+	// job finishes.
+	args.TestingKnobs = &sql.StreamingTestingKnobs{
+		AfterCutoverStarted: func() { <-cutoverCh },
+	}
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	waitBeforeCh := make(chan struct{})
+	waitAfterCh := make(chan struct{})
+	registry := c.DestSysServer.JobRegistry().(*jobs.Registry)
+	registry.TestingWrapResumerConstructor(jobspb.TypeReplicationStreamIngestion,
+		func(raw jobs.Resumer) jobs.Resumer {
+			r := blockingResumer{
+				orig:       raw,
+				waitBefore: waitBeforeCh,
+				waitAfter:  waitAfterCh,
+				ctx:        ctx,
+			}
+			return &r
+		})
+	defer ctxCancel()
+	unblockResumerStart := func() {
+		waitBeforeCh <- struct{}{}
+	}
+	unblockResumerExit := func() {
+		waitAfterCh <- struct{}{}
+	}
+
+	getTenantStatus := func() string {
+		var status string
+		c.DestSysSQL.QueryRow(c.T, fmt.Sprintf("SELECT data_state FROM [SHOW TENANT %s]",
+			c.Args.DestTenantName)).Scan(&status)
+		return status
+	}
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	require.Equal(c.T, "initializing replication", getTenantStatus())
+	unblockResumerStart()
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	require.Equal(c.T, "replicating", getTenantStatus())
+
+	c.DestSysSQL.Exec(c.T, fmt.Sprintf("ALTER TENANT %s COMPLETE REPLICATION TO LATEST", args.DestTenantName))
+
+	testutils.SucceedsSoon(t, func() error {
+		s := getTenantStatus()
+		if s == "replication pending cutover" {
+			return errors.Errorf("tenant status is still 'replication pending cutover', waiting")
+		}
+		require.Equal(c.T, "replication cutting over", s)
+		return nil
+	})
+
+	// Done, the tenant is cutting over, unblock the job.
+	cutoverCh <- struct{}{}
+	unblockResumerExit()
+	jobutils.WaitForJobToSucceed(c.T, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	require.Equal(c.T, "ready", getTenantStatus())
+}
+
+// TestTenantReplicationStatus verifies replication status errors.
+func TestTenantReplicationStatus(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	registry := c.DestSysServer.JobRegistry().(*jobs.Registry)
+
+	// Pass a nonexistent job id.
+	_, status, err := getReplicationStatsAndStatus(ctx, registry, nil, jobspb.JobID(-1))
+	require.ErrorContains(t, err, "job with ID -1 does not exist")
+	require.Equal(t, "replication error", status)
+
+	// The producer job in the source cluster should not have a replication status.
+	registry = c.SrcSysServer.JobRegistry().(*jobs.Registry)
+	_, status, err = getReplicationStatsAndStatus(ctx, registry, nil, jobspb.JobID(producerJobID))
+	require.ErrorContains(t, err, "is not a stream ingestion job")
+	require.Equal(t, "replication error", status)
+}
+
+// TestAlterTenantHandleFutureProtectedTimestamp verifies that cutting over "TO
+// LATEST" doesn't fail if the destination cluster clock is ahead of the source
+// cluster clock. See issue #96477.
+func TestAlterTenantHandleFutureProtectedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	args := replicationtestutils.DefaultTenantStreamingClustersArgs
+	c, cleanup := replicationtestutils.CreateTenantStreamingClusters(ctx, t, args)
+	defer cleanup()
+
+	// Push the clock on the destination cluster forward.
+	destNow := c.DestCluster.Server(0).Clock().NowAsClockTimestamp()
+	destNow.WallTime += (200 * time.Millisecond).Nanoseconds()
+	c.DestCluster.Server(0).Clock().Update(destNow)
+
+	producerJobID, ingestionJobID := c.StartStreamReplication(ctx)
+
+	jobutils.WaitForJobToRun(t, c.SrcSysSQL, jobspb.JobID(producerJobID))
+	jobutils.WaitForJobToRun(t, c.DestSysSQL, jobspb.JobID(ingestionJobID))
+	c.WaitUntilReplicatedTime(c.SrcCluster.Server(0).Clock().Now(), jobspb.JobID(ingestionJobID))
+
+	c.DestSysSQL.Exec(c.T, `ALTER TENANT $1 COMPLETE REPLICATION TO LATEST`, args.DestTenantName)
+}
+
+func TestAlterTenantStartReplicationAfterRestore(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+
+	var enforcedGC struct {
+		syncutil.Mutex
+		ts hlc.Timestamp
+	}
+
+	testingRequestFilter := func(_ context.Context, ba *kvpb.BatchRequest) *kvpb.Error {
+		for _, req := range ba.Requests {
+			if revReq := req.GetRevertRange(); revReq != nil {
+				enforcedGC.Lock()
+				defer enforcedGC.Unlock()
+				if enforcedGC.ts.IsSet() && revReq.TargetTime.Less(enforcedGC.ts) {
+					return kvpb.NewError(&kvpb.BatchTimestampBeforeGCError{
+						Timestamp: revReq.TargetTime,
+						Threshold: enforcedGC.ts,
+					})
+				}
+			}
+		}
+		return nil
+	}
+
+	nodelocalCleanup := nodelocal.ReplaceNodeLocalForTesting(t.TempDir())
+	defer nodelocalCleanup()
+
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestControlsTenantsExplicitly,
+		Knobs: base.TestingKnobs{
+			Store: &kvserver.StoreTestingKnobs{
+				TestingRequestFilter: testingRequestFilter,
+			},
+		},
+	})
+	defer srv.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+
+	db.Exec(t, "CREATE TENANT t1")
+	db.Exec(t, "BACKUP TENANT 3 TO 'nodelocal://1/t'")
+
+	afterBackup := srv.Clock().Now()
+	enforcedGC.Lock()
+	enforcedGC.ts = afterBackup
+	enforcedGC.Unlock()
+
+	u, cleanupURLA := sqlutils.PGUrl(t, srv.SQLAddr(), t.Name(), url.User(username.RootUser))
+	defer cleanupURLA()
+
+	db.Exec(t, "RESTORE TENANT 3 FROM 'nodelocal://1/t' WITH TENANT = '5', TENANT_NAME = 't2'")
+	db.Exec(t, "ALTER TENANT t2 START REPLICATION OF t1 ON $1", u.String())
+	srv.JobRegistry().(*jobs.Registry).TestingNudgeAdoptionQueue()
+
+	_, ingestionJobID := replicationtestutils.GetStreamJobIds(t, ctx, db, "t2")
+	srcTime := srv.Clock().Now()
+	replicationtestutils.WaitUntilReplicatedTime(t, srcTime, db, catpb.JobID(ingestionJobID))
+}
+
+// TestAlterTenantStartReplicationJobCreationFailure verifies that if creating
+// the replication job fails when starting replication into an existing
+// virtual cluster, the virtual cluster's record is left unchanged.
+func TestAlterTenantStartReplicationJobCreationFailure(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+
+	nodelocalCleanup := nodelocal.ReplaceNodeLocalForTesting(t.TempDir())
+	defer nodelocalCleanup()
+
+	var failJobCreation atomic.Bool
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestControlsTenantsExplicitly,
+		Knobs: base.TestingKnobs{
+			Streaming: &sql.StreamingTestingKnobs{
+				BeforeIngestionJobCreation: func(context.Context) error {
+					if failJobCreation.Load() {
+						return errors.New("injected job creation failure")
+					}
+					return nil
+				},
+			},
+		},
+	})
+	defer srv.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "CREATE TENANT t1")
+	db.Exec(t, "BACKUP TENANT 3 TO 'nodelocal://1/t'")
+	db.Exec(t, "RESTORE TENANT 3 FROM 'nodelocal://1/t' WITH TENANT = '5', TENANT_NAME = 't2'")
+
+	u, cleanupURL := sqlutils.PGUrl(t, srv.SQLAddr(), t.Name(), url.User(username.RootUser))
+	defer cleanupURL()
+
+	countIngestionJobs := func() int {
+		var count int
+		db.QueryRow(t, "SELECT count(*) FROM [SHOW JOBS] WHERE job_type = $1",
+			jobspb.TypeReplicationStreamIngestion.String()).Scan(&count)
+		return count
+	}
+
+	failJobCreation.Store(true)
+	db.ExpectErr(t, "creating replication job: injected job creation failure",
+		"ALTER TENANT t2 START REPLICATION OF t1 ON $1", u.String())
+
+	var dataState string
+	db.QueryRow(t, "SELECT data_state FROM [SHOW TENANT t2]").Scan(&dataState)
+	require.Equal(t, "ready", dataState)
+	require.Equal(t, 0, countIngestionJobs())
+
+	// Once job creation succeeds, replication can be started as usual.
+	failJobCreation.Store(false)
+	db.Exec(t, "ALTER TENANT t2 START REPLICATION OF t1 ON $1", u.String())
+	require.Equal(t, 1, countIngestionJobs())
+}
+
+// TestAlterTenantStartReplicationValidateOnly verifies that starting
+// replication with VALIDATE_ONLY reports the resume timestamp and source ID
+// without creating a job or modifying the virtual cluster's record.
+func TestAlterTenantStartReplicationValidateOnly(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+
+	nodelocalCleanup := nodelocal.ReplaceNodeLocalForTesting(t.TempDir())
+	defer nodelocalCleanup()
+
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestControlsTenantsExplicitly,
+	})
+	defer srv.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "CREATE TENANT t1")
+	db.Exec(t, "BACKUP TENANT 3 TO 'nodelocal://1/t'")
+	db.Exec(t, "RESTORE TENANT 3 FROM 'nodelocal://1/t' WITH TENANT = '5', TENANT_NAME = 't2'")
+
+	u, cleanupURL := sqlutils.PGUrl(t, srv.SQLAddr(), t.Name(), url.User(username.RootUser))
+	defer cleanupURL()
+
+	tenantRecord := func() [][]string {
+		return db.QueryStr(t, "SELECT data_state, service_mode FROM [SHOW TENANT t2]")
+	}
+	countIngestionJobs := func() int {
+		var count int
+		db.QueryRow(t, "SELECT count(*) FROM [SHOW JOBS] WHERE job_type = $1",
+			jobspb.TypeReplicationStreamIngestion.String()).Scan(&count)
+		return count
+	}
+	before := tenantRecord()
+
+	var resumeStr, srcID string
+	db.QueryRow(t, "ALTER TENANT t2 START REPLICATION OF t1 ON $1 WITH VALIDATE_ONLY",
+		u.String()).Scan(&resumeStr, &srcID)
+	require.True(t, replicationtestutils.DecimalTimeToHLC(t, resumeStr).IsSet())
+	var expectedSrcID string
+	db.QueryRow(t, "SELECT crdb_internal.cluster_id()::string||':'||id::string FROM [SHOW TENANT t1]").
+		Scan(&expectedSrcID)
+	require.Equal(t, expectedSrcID, srcID)
+
+	require.Equal(t, before, tenantRecord())
+	require.Equal(t, 0, countIngestionJobs())
+
+	db.ExpectErr(t, "VALIDATE_ONLY is only supported by ALTER VIRTUAL CLUSTER ... START REPLICATION",
+		"ALTER TENANT t2 SET REPLICATION VALIDATE_ONLY")
+	db.ExpectErr(t, "VALIDATE_ONLY is only supported by ALTER VIRTUAL CLUSTER ... START REPLICATION",
+		"CREATE TENANT t3 FROM REPLICATION OF t1 ON $1 WITH VALIDATE_ONLY", u.String())
+}
+
+// TestAlterTenantReplicationPreconditions verifies that the license and the
+// system tenant preconditions of ALTER VIRTUAL CLUSTER REPLICATION are each
+// checked before any of the statement's options are evaluated.
+func TestAlterTenantReplicationPreconditions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestControlsTenantsExplicitly,
+	})
+	defer srv.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "CREATE TENANT t1")
+
+	// The PRIORITY is invalid, so that evaluating the options fails if the
+	// preconditions are satisfied.
+	const stmt = `ALTER TENANT t1 SET REPLICATION PRIORITY = 'urgent'`
+
+	t.Run("no-license", func(t *testing.T) {
+		defer utilccl.TestingDisableEnterprise()()
+		db.ExpectErr(t, "use of ALTER VIRTUAL CLUSTER REPLICATION requires an enterprise license", stmt)
+	})
+
+	t.Run("secondary-tenant", func(t *testing.T) {
+		_, tenantDB, err := srv.TenantController().StartSharedProcessTenant(ctx,
+			base.TestSharedProcessTenantArgs{TenantName: "app"})
+		require.NoError(t, err)
+		sqlutils.MakeSQLRunner(tenantDB).ExpectErr(t, "only the system tenant can alter tenant", stmt)
+	})
+
+	t.Run("preconditions-satisfied", func(t *testing.T) {
+		db.ExpectErr(t, `invalid PRIORITY "urgent"`, stmt)
+	})
+}
+
+// TestAlterTenantStartReplicationFromItself verifies that a virtual cluster
+// cannot be configured to replicate from itself.
+func TestAlterTenantStartReplicationFromItself(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestControlsTenantsExplicitly,
+	})
+	defer srv.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "CREATE TENANT t1")
+
+	u, cleanupURL := sqlutils.PGUrl(t, srv.SQLAddr(), t.Name(), url.User(username.RootUser))
+	defer cleanupURL()
+
+	db.ExpectErr(t, `cannot replicate virtual cluster "t1" \(3\) from itself`,
+		"ALTER TENANT t1 START REPLICATION OF t1 ON $1", u.String())
+}
+
+// TestAlterTenantRestartReplicationZeroRetention verifies that starting
+// replication into an existing virtual cluster with a zero retention is
+// rejected before a client to the source is created.
+func TestAlterTenantRestartReplicationZeroRetention(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var clientsCreated atomic.Int32
+	defer func(prev func(*url.URL, descs.DB) (streamclient.Client, error)) {
+		streamclient.RandomGenClientBuilder = prev
+	}(streamclient.RandomGenClientBuilder)
+	streamclient.RandomGenClientBuilder = func(*url.URL, descs.DB) (streamclient.Client, error) {
+		clientsCreated.Add(1)
+		return nil, errors.New("unexpected stream client creation")
+	}
+
+	ctx := context.Background()
+	srv, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestControlsTenantsExplicitly,
+	})
+	defer srv.Stopper().Stop(ctx)
+
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.Exec(t, "CREATE TENANT t1")
+
+	db.ExpectErr(t, `cannot start replication into existing virtual cluster "t1" with a retention of 0s`,
+		"ALTER TENANT t1 START REPLICATION OF src ON 'randomgen://' WITH RETENTION = '0s'")
+	require.Zero(t, clientsCreated.Load())
+
+	require.NoError(t, validateRestartRetention("t1", 1))
+}
+
+func TestValidateReplicationSource(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	srcAddr := tree.NewStrVal("postgres://source")
+	srcTenant := &tree.TenantSpec{IsName: true, Expr: tree.NewStrVal("src")}
+	for _, tc := range []struct {
+		name   string
+		stmt   tree.AlterTenantReplication
+		expErr string
+	}{
+		{name: "both", stmt: tree.AlterTenantReplication{
+			ReplicationSourceAddress: srcAddr, ReplicationSourceTenantName: srcTenant,
+		}},
+		{name: "neither", stmt: tree.AlterTenantReplication{Command: tree.PauseJob}},
+		{name: "address-only", stmt: tree.AlterTenantReplication{
+			ReplicationSourceAddress: srcAddr,
+		}, expErr: "a replication source address requires the name of the source virtual cluster"},
+		{name: "tenant-only", stmt: tree.AlterTenantReplication{
+			ReplicationSourceTenantName: srcTenant,
+		}, expErr: "a replication source virtual cluster requires the address of the source cluster"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateReplicationSource(&tc.stmt)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expErr)
+			require.Equal(t, pgcode.Syntax, pgerror.GetPGCode(err))
+		})
+	}
+}
+
+// TestEvalTenantReplicationOptions verifies the evaluation and validation of
+// the options accepted by CREATE/ALTER VIRTUAL CLUSTER ... REPLICATION.
+func TestEvalTenantReplicationOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	defer evalCtx.Stop(ctx)
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	exprEval := exprutil.MakeEvaluator("test", &semaCtx, &evalCtx)
+
+	zoneGCTTLs := map[zonepb.NamedZone]int32{zonepb.DefaultZoneName: 14400}
+	zoneGCTTL := func(_ context.Context, zone zonepb.NamedZone) (int32, error) {
+		ttl, ok := zoneGCTTLs[zone]
+		if !ok {
+			return 0, errors.Newf("no zone config for %s", zone)
+		}
+		return ttl, nil
+	}
+	evalOptions := func(
+		options tree.TenantReplicationOptions,
+	) (*ResolvedTenantReplicationOptions, error) {
+		return EvalTenantReplicationOptions(ctx, options, exprEval, &evalCtx, &semaCtx, zoneGCTTL, "test")
+	}
+
+	t.Run("retention-out-of-range", func(t *testing.T) {
+		_, err := evalOptions(tree.TenantReplicationOptions{
+			Retention: tree.NewStrVal("100 years"),
+		})
+		require.ErrorContains(t, err, "retention should result in a number of seconds between 0 and")
+		require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			Retention: &tree.DInterval{Duration: duration.MakeDuration(0, math.MaxInt64/2, 0)},
+		})
+		require.ErrorContains(t, err, "interval conversion error")
+		require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+	})
+
+	t.Run("retention-below-min", func(t *testing.T) {
+		crosscluster.MinReplicationRetention.Override(ctx, &evalCtx.Settings.SV, time.Hour)
+		defer crosscluster.MinReplicationRetention.Override(ctx, &evalCtx.Settings.SV, 0)
+
+		_, err := evalOptions(tree.TenantReplicationOptions{
+			Retention: tree.NewStrVal("30m"),
+		})
+		require.ErrorContains(t, err,
+			"retention 30m0s is below the minimum of 1h0m0s set by physical_replication.consumer.min_retention")
+		require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+
+		// The floor also applies to a retention inherited from a zone.
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			RetentionFromZone: tree.NewStrVal("default"),
+		})
+		require.NoError(t, err)
+		zoneGCTTLs[zonepb.DefaultZoneName] = 600
+		defer func() { zoneGCTTLs[zonepb.DefaultZoneName] = 14400 }()
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			RetentionFromZone: tree.NewStrVal("default"),
+		})
+		require.ErrorContains(t, err, "retention 10m0s is below the minimum of 1h0m0s")
+
+		for _, retention := range []string{"1h", "4h"} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				Retention: tree.NewStrVal(retention),
+			})
+			require.NoError(t, err)
+			_, ok := options.GetRetention()
+			require.True(t, ok)
+		}
+
+		// Options that don't set a retention are unaffected by the floor.
+		_, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("retention-from-zone", func(t *testing.T) {
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			RetentionFromZone: tree.NewStrVal("default"),
+		})
+		require.NoError(t, err)
+		retention, ok := options.GetRetention()
+		require.True(t, ok)
+		require.Equal(t, int32(14400), retention)
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			RetentionFromZone: tree.NewStrVal("nonexistent"),
+		})
+		require.ErrorContains(t, err, `invalid RETENTION: unknown zone "nonexistent"`)
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			RetentionFromZone: tree.NewStrVal("meta"),
+		})
+		require.ErrorContains(t, err, `resolving GC TTL of zone "meta"`)
+	})
+
+	t.Run("initial-retention", func(t *testing.T) {
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			InitialRetention: tree.NewStrVal("72h"),
+			Retention:        tree.NewStrVal("24h"),
+		})
+		require.NoError(t, err)
+		initial, ok := options.GetInitialRetention()
+		require.True(t, ok)
+		require.Equal(t, int32(72*60*60), initial)
+		retention, ok := options.GetRetention()
+		require.True(t, ok)
+		require.Equal(t, int32(24*60*60), retention)
+		require.True(t, options.DestinationOptionsSet())
+
+		// An initial retention alone is validated once it is persisted, against
+		// the steady state retention it is combined with.
+		options, err = evalOptions(tree.TenantReplicationOptions{
+			InitialRetention: tree.NewStrVal("1h"),
+		})
+		require.NoError(t, err)
+		initial, ok = options.GetInitialRetention()
+		require.True(t, ok)
+		require.Equal(t, int32(60*60), initial)
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			InitialRetention: tree.NewStrVal("12h"),
+			Retention:        tree.NewStrVal("24h"),
+		})
+		require.ErrorContains(t, err, "INITIAL_RETENTION (12h0m0s) must not be shorter than RETENTION (24h0m0s)")
+		require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			InitialRetention: tree.NewStrVal("100 years"),
+		})
+		require.ErrorContains(t, err, "initial retention should result in a number of seconds between 0 and")
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetInitialRetention()
+		require.False(t, ok)
+	})
+
+	t.Run("seed-from-backup", func(t *testing.T) {
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			SeedFromBackup: tree.NewStrVal("nodelocal://1/backup"),
+		})
+		require.NoError(t, err)
+		uri, ok := options.GetSeedFromBackup()
+		require.True(t, ok)
+		require.Equal(t, "nodelocal://1/backup", uri)
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			SeedFromBackup: tree.NewStrVal("no-scheme"),
+		})
+		require.ErrorContains(t, err, "invalid SEED_FROM_BACKUP URI")
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			SeedFromBackup: tree.NewStrVal("nodelocal://%zz"),
+		})
+		require.ErrorContains(t, err, "invalid SEED_FROM_BACKUP URI")
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetSeedFromBackup()
+		require.False(t, ok)
+	})
+
+	t.Run("priority", func(t *testing.T) {
+		for _, priority := range []string{"low", "normal", "high"} {
+			t.Run(priority, func(t *testing.T) {
+				options, err := evalOptions(tree.TenantReplicationOptions{
+					Priority: tree.NewStrVal(priority),
+				})
+				require.NoError(t, err)
+				got, ok := options.GetPriority()
+				require.True(t, ok)
+				require.Equal(t, priority, got)
+				require.True(t, options.DestinationOptionsSet())
+			})
+		}
+
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			Priority: tree.NewStrVal("HIGH"),
+		})
+		require.NoError(t, err)
+		got, ok := options.GetPriority()
+		require.True(t, ok)
+		require.Equal(t, "high", got)
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			Priority: tree.NewStrVal("urgent"),
+		})
+		require.ErrorContains(t, err, `invalid PRIORITY "urgent"`)
+	})
+
+	t.Run("resume-partitions", func(t *testing.T) {
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			ResumePartitions: tree.NewStrVal(
+				`{"1": "1700000000000000000.0000000000", "2": "1700000000000000001.0000000002"}`),
+		})
+		require.NoError(t, err)
+		partitions, ok := options.GetResumePartitions()
+		require.True(t, ok)
+		require.Equal(t, map[string]hlc.Timestamp{
+			"1": {WallTime: 1700000000000000000},
+			"2": {WallTime: 1700000000000000001, Logical: 2},
+		}, partitions)
+		require.True(t, options.DestinationOptionsSet())
+
+		for _, tc := range []struct {
+			encoded string
+			err     string
+		}{
+			{encoded: `not json`, err: "invalid RESUME_PARTITIONS"},
+			{encoded: `["1700000000000000000.0000000000"]`, err: "invalid RESUME_PARTITIONS"},
+			{encoded: `{"1": 1700000000000000000}`, err: "invalid RESUME_PARTITIONS"},
+			{encoded: `{}`, err: "at least one partition must be specified"},
+			{encoded: `{"": "1700000000000000000.0000000000"}`, err: "partition ID cannot be empty"},
+			{encoded: `{"1": "yesterday"}`, err: `invalid RESUME_PARTITIONS timestamp "yesterday" for partition "1"`},
+			{encoded: `{"1": "0"}`, err: "must be non-zero"},
+		} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				ResumePartitions: tree.NewStrVal(tc.encoded),
+			})
+			require.ErrorContains(t, err, tc.err, "RESUME_PARTITIONS = %s", tc.encoded)
+		}
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetResumePartitions()
+		require.False(t, ok)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			Owner: tree.NewStrVal("team-dr"),
+		})
+		require.NoError(t, err)
+		owner, ok := options.GetOwner()
+		require.True(t, ok)
+		require.Equal(t, "team-dr", owner)
+		require.True(t, options.DestinationOptionsSet())
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			Owner: tree.NewStrVal(""),
+		})
+		require.ErrorContains(t, err, "invalid OWNER: cannot be empty")
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			Owner: tree.NewStrVal(strings.Repeat("a", maxReplicationOwnerLength+1)),
+		})
+		require.ErrorContains(t, err, "invalid OWNER: must be at most")
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetOwner()
+		require.False(t, ok)
+	})
+
+	t.Run("service-mode-on-complete", func(t *testing.T) {
+		for name, expected := range map[string]mtinfopb.TenantServiceMode{
+			"shared":   mtinfopb.ServiceModeShared,
+			"EXTERNAL": mtinfopb.ServiceModeExternal,
+		} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				ServiceModeOnComplete: tree.NewStrVal(name),
+			})
+			require.NoError(t, err)
+			mode, ok := options.GetServiceModeOnComplete()
+			require.True(t, ok)
+			require.Equal(t, expected, mode)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		for _, name := range []string{"none", "stopping", "bogus"} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				ServiceModeOnComplete: tree.NewStrVal(name),
+			})
+			require.ErrorContains(t, err, "invalid SERVICE_MODE_ON_COMPLETE")
+		}
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetServiceModeOnComplete()
+		require.False(t, ok)
+	})
+
+	t.Run("pause-on-disk-full", func(t *testing.T) {
+		for _, percent := range []int64{0, 90, 100} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				PauseOnDiskFull: tree.NewDInt(tree.DInt(percent)),
+			})
+			require.NoError(t, err)
+			threshold, ok := options.GetPauseOnDiskFull()
+			require.True(t, ok)
+			require.Equal(t, int32(percent), threshold)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		for _, percent := range []int64{-1, 101, math.MaxInt32 + 1} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				PauseOnDiskFull: tree.NewDInt(tree.DInt(percent)),
+			})
+			require.ErrorContains(t, err, "invalid PAUSE_ON_DISK_FULL")
+		}
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetPauseOnDiskFull()
+		require.False(t, ok)
+	})
+
+	t.Run("max-partition-streams", func(t *testing.T) {
+		for _, streams := range []int64{1, 8, math.MaxInt32} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				MaxPartitionStreams: tree.NewDInt(tree.DInt(streams)),
+			})
+			require.NoError(t, err)
+			limit, ok := options.GetMaxPartitionStreams()
+			require.True(t, ok)
+			require.Equal(t, int32(streams), limit)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		for _, streams := range []int64{0, -1, math.MaxInt32 + 1} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				MaxPartitionStreams: tree.NewDInt(tree.DInt(streams)),
+			})
+			require.ErrorContains(t, err, "invalid MAX_PARTITION_STREAMS")
+		}
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetMaxPartitionStreams()
+		require.False(t, ok)
+	})
+
+	t.Run("pts-advance-interval", func(t *testing.T) {
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			PTSAdvanceInterval: tree.NewStrVal("30s"),
+		})
+		require.NoError(t, err)
+		interval, ok := options.GetPTSAdvanceInterval()
+		require.True(t, ok)
+		require.Equal(t, 30*time.Second, interval)
+		require.True(t, options.DestinationOptionsSet())
+
+		for _, invalid := range []string{"0s", "-1m"} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				PTSAdvanceInterval: tree.NewStrVal(invalid),
+			})
+			require.ErrorContains(t, err, "invalid PTS_ADVANCE_INTERVAL")
+		}
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			PTSAdvanceInterval: tree.NewStrVal("soon"),
+		})
+		require.Error(t, err)
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetPTSAdvanceInterval()
+		require.False(t, ok)
+	})
+
+	t.Run("verify-checksums", func(t *testing.T) {
+		for _, verify := range []bool{true, false} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				VerifyChecksums: tree.MakeDBool(tree.DBool(verify)),
+			})
+			require.NoError(t, err)
+			got, ok := options.GetVerifyChecksums()
+			require.True(t, ok)
+			require.Equal(t, verify, got)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetVerifyChecksums()
+		require.False(t, ok)
+	})
+
+	t.Run("event-sink", func(t *testing.T) {
+		for _, sink := range []string{
+			"kafka://broker:9092?topic_name=dr-events",
+			"webhook-https://dashboards.example.com/dr?insecure_tls_skip_verify=true",
+		} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				EventSink: tree.NewStrVal(sink),
+			})
+			require.NoError(t, err)
+			got, ok := options.GetEventSink()
+			require.True(t, ok)
+			require.Equal(t, sink, got)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		for _, tc := range []struct {
+			sink   string
+			expErr string
+		}{
+			{sink: "s3://bucket/events", expErr: `unsupported scheme "s3"`},
+			{sink: "webhook-http://dashboards.example.com", expErr: `unsupported scheme "webhook-http"`},
+			{sink: "broker:9092", expErr: "unsupported scheme"},
+			{sink: "kafka:///dr-events", expErr: "missing host"},
+			{sink: "kafka://%zz", expErr: "invalid EVENT_SINK URI"},
+		} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				EventSink: tree.NewStrVal(tc.sink),
+			})
+			require.ErrorContains(t, err, tc.expErr, tc.sink)
+		}
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetEventSink()
+		require.False(t, ok)
+	})
+
+	t.Run("target-nodes", func(t *testing.T) {
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			TargetNodes: tree.NewStrVal("5, 1,3"),
+		})
+		require.NoError(t, err)
+		nodeIDs, ok := options.GetTargetNodes()
+		require.True(t, ok)
+		require.Equal(t, []roachpb.NodeID{1, 3, 5}, nodeIDs)
+		require.True(t, options.DestinationOptionsSet())
+
+		for _, tc := range []struct {
+			nodes  string
+			expErr string
+		}{
+			{nodes: "", expErr: `"" is not a valid node ID`},
+			{nodes: "1,,3", expErr: `"" is not a valid node ID`},
+			{nodes: "1,n3", expErr: `"n3" is not a valid node ID`},
+			{nodes: "0", expErr: `"0" is not a valid node ID`},
+			{nodes: "-2", expErr: `"-2" is not a valid node ID`},
+			{nodes: "1,3,1", expErr: "node 1 is listed more than once"},
+		} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				TargetNodes: tree.NewStrVal(tc.nodes),
+			})
+			require.ErrorContains(t, err, tc.expErr, tc.nodes)
+		}
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetTargetNodes()
+		require.False(t, ok)
+	})
+
+	t.Run("on-error", func(t *testing.T) {
+		for _, tc := range []struct {
+			onError string
+			exp     string
+		}{
+			{onError: "pause", exp: "pause"},
+			{onError: "fail", exp: "fail"},
+			{onError: "FAIL", exp: "fail"},
+		} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				OnError: tree.NewStrVal(tc.onError),
+			})
+			require.NoError(t, err)
+			onError, ok := options.GetOnError()
+			require.True(t, ok)
+			require.Equal(t, tc.exp, onError)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		_, err := evalOptions(tree.TenantReplicationOptions{
+			OnError: tree.NewStrVal("retry"),
+		})
+		require.ErrorContains(t, err, `invalid ON_ERROR "retry": must be one of pause, fail`)
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetOnError()
+		require.False(t, ok)
+	})
+
+	t.Run("auto-cutover", func(t *testing.T) {
+		evalCtx.StmtTimestamp = timeutil.Now()
+
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			AutoCutover: tree.NewStrVal("2100-01-01 00:00:00"),
+		})
+		require.NoError(t, err)
+		ts, ok := options.GetAutoCutover()
+		require.True(t, ok)
+		require.Equal(t, time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), ts.GoTime().UTC())
+		require.True(t, options.DestinationOptionsSet())
+
+		_, err = evalOptions(tree.TenantReplicationOptions{
+			AutoCutover: tree.NewStrVal("2000-01-01 00:00:00"),
+		})
+		require.ErrorContains(t, err, "must be in the future")
+		require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+
+		// NULL clears a scheduled cutover.
+		options, err = evalOptions(tree.TenantReplicationOptions{
+			AutoCutover: tree.DNull,
+		})
+		require.NoError(t, err)
+		ts, ok = options.GetAutoCutover()
+		require.True(t, ok)
+		require.True(t, ts.IsEmpty())
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetAutoCutover()
+		require.False(t, ok)
+	})
+
+	t.Run("idle-timeout", func(t *testing.T) {
+		options, err := evalOptions(tree.TenantReplicationOptions{
+			IdleTimeout: tree.NewStrVal("1h"),
+		})
+		require.NoError(t, err)
+		timeout, ok := options.GetIdleTimeout()
+		require.True(t, ok)
+		require.Equal(t, time.Hour, timeout)
+		require.True(t, options.DestinationOptionsSet())
+
+		for _, invalid := range []string{"0s", "-1m"} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				IdleTimeout: tree.NewStrVal(invalid),
+			})
+			require.ErrorContains(t, err, "must be a positive duration")
+			require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+		}
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetIdleTimeout()
+		require.False(t, ok)
+	})
+
+	t.Run("span-config", func(t *testing.T) {
+		for _, tc := range []struct {
+			mode string
+			exp  string
+		}{
+			{mode: "inherit", exp: "inherit"},
+			{mode: "local", exp: "local"},
+			{mode: "LOCAL", exp: "local"},
+		} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				SpanConfig: tree.NewStrVal(tc.mode),
+			})
+			require.NoError(t, err)
+			mode, ok := options.GetSpanConfigMode()
+			require.True(t, ok)
+			require.Equal(t, tc.exp, mode)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		_, err := evalOptions(tree.TenantReplicationOptions{
+			SpanConfig: tree.NewStrVal("source"),
+		})
+		require.ErrorContains(t, err, `invalid SPAN_CONFIG "source": must be one of inherit, local`)
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetSpanConfigMode()
+		require.False(t, ok)
+	})
+
+	t.Run("encryption-key", func(t *testing.T) {
+		const key = "aws-kms://key-id?AUTH=specified&AWS_SECRET_ACCESS_KEY=secret"
+		opts := tree.TenantReplicationOptions{EncryptionKey: tree.NewStrVal(key)}
+		options, err := evalOptions(opts)
+		require.NoError(t, err)
+		stored, ok := options.GetEncryptionKey()
+		require.True(t, ok)
+		require.Equal(t, key, stored)
+		require.True(t, options.DestinationOptionsSet())
+
+		// The key is stored as given, but never formatted.
+		require.Equal(t, "ENCRYPTION_KEY = '*****'", tree.AsString(&opts))
+		require.Equal(t, fmt.Sprintf("ENCRYPTION_KEY = '%s'", key),
+			tree.AsStringWithFlags(&opts, tree.FmtShowPasswords))
+
+		for _, tc := range []struct {
+			key string
+			err string
+		}{
+			{key: "kms://", err: "invalid ENCRYPTION_KEY: missing key identifier"},
+			{key: "file:///secret", err: `invalid ENCRYPTION_KEY: unsupported scheme "file"`},
+			{key: "kms://%zz/secret", err: "invalid ENCRYPTION_KEY: malformed key reference"},
+		} {
+			_, err := evalOptions(tree.TenantReplicationOptions{EncryptionKey: tree.NewStrVal(tc.key)})
+			require.ErrorContains(t, err, tc.err)
+			require.NotContains(t, err.Error(), "secret")
+		}
+
+		options, err = evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok = options.GetEncryptionKey()
+		require.False(t, ok)
+	})
+
+	t.Run("checkpoint-interval", func(t *testing.T) {
+		for _, tc := range []struct {
+			interval string
+			exp      time.Duration
+		}{
+			{interval: "15s", exp: 15 * time.Second},
+			{interval: "500ms", exp: 500 * time.Millisecond},
+			{interval: "10m", exp: maxCheckpointInterval},
+		} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				CheckpointInterval: tree.NewStrVal(tc.interval),
+			})
+			require.NoError(t, err)
+			interval, ok := options.GetCheckpointInterval()
+			require.True(t, ok)
+			require.Equal(t, tc.exp, interval)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		for _, invalid := range []string{"0s", "-15s", "10m1s", "1h"} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				CheckpointInterval: tree.NewStrVal(invalid),
+			})
+			require.ErrorContains(t, err, "must be a positive duration of at most 10m0s")
+			require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+		}
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetCheckpointInterval()
+		require.False(t, ok)
+	})
+
+	t.Run("source-conn-pool", func(t *testing.T) {
+		for _, size := range []int{1, 16, maxSourceConnPool} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				SourceConnPool: tree.NewDInt(tree.DInt(size)),
+			})
+			require.NoError(t, err)
+			stored, ok := options.GetSourceConnPool()
+			require.True(t, ok)
+			require.Equal(t, int32(size), stored)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		for _, invalid := range []int{0, -4, maxSourceConnPool + 1} {
+			_, err := evalOptions(tree.TenantReplicationOptions{
+				SourceConnPool: tree.NewDInt(tree.DInt(invalid)),
+			})
+			require.ErrorContains(t, err, fmt.Sprintf("invalid SOURCE_CONN_POOL %d: must be between 1 and 64", invalid))
+			require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+		}
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetSourceConnPool()
+		require.False(t, ok)
+	})
+
+	t.Run("ingest-mode", func(t *testing.T) {
+		for _, tc := range []struct {
+			mode string
+			exp  string
+		}{
+			{mode: "raw", exp: ingestModeRaw},
+			{mode: "row", exp: ingestModeRow},
+			{mode: "ROW", exp: ingestModeRow},
+		} {
+			options, err := evalOptions(tree.TenantReplicationOptions{
+				IngestMode: tree.NewStrVal(tc.mode),
+			})
+			require.NoError(t, err)
+			mode, ok := options.GetIngestMode()
+			require.True(t, ok)
+			require.Equal(t, tc.exp, mode)
+			require.True(t, options.DestinationOptionsSet())
+		}
+
+		_, err := evalOptions(tree.TenantReplicationOptions{
+			IngestMode: tree.NewStrVal("sst"),
+		})
+		require.ErrorContains(t, err, `invalid INGEST_MODE "sst": must be one of raw, row`)
+		require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+
+		options, err := evalOptions(tree.TenantReplicationOptions{})
+		require.NoError(t, err)
+		_, ok := options.GetIngestMode()
+		require.False(t, ok)
+	})
+}
+
+func TestValidateCutoverLogical(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	replicatedTime := hlc.Timestamp{WallTime: 100, Logical: 2}
+	for _, tc := range []struct {
+		name        string
+		cutoverTime hlc.Timestamp
+		expErr      string
+	}{
+		{name: "wall-time-only", cutoverTime: hlc.Timestamp{WallTime: 200}},
+		{name: "logical-below-replicated", cutoverTime: hlc.Timestamp{WallTime: 50, Logical: 7}},
+		{name: "logical-at-replicated", cutoverTime: replicatedTime},
+		{
+			name:        "logical-above-replicated-tick",
+			cutoverTime: hlc.Timestamp{WallTime: 100, Logical: 3},
+			expErr:      "has a logical component and is above the replicated time",
+		},
+		{
+			name:        "logical-above-replicated-wall",
+			cutoverTime: hlc.Timestamp{WallTime: 200, Logical: 1},
+			expErr:      "has a logical component and is above the replicated time",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCutoverLogical(tc.cutoverTime, replicatedTime)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expErr)
+				require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+			}
+		})
+	}
+}
+
+func TestValidateCutoverDataLoss(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	replicatedTime := hlc.Timestamp{WallTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()}
+	const threshold = time.Hour
+	for _, tc := range []struct {
+		name          string
+		cutoverTime   hlc.Timestamp
+		allowDataLoss bool
+		disabled      bool
+		expErr        string
+	}{
+		{name: "near-frontier", cutoverTime: replicatedTime.AddDuration(-time.Minute)},
+		{name: "at-threshold", cutoverTime: replicatedTime.AddDuration(-threshold)},
+		{name: "above-frontier", cutoverTime: replicatedTime.AddDuration(time.Hour)},
+		{
+			name:        "far-behind",
+			cutoverTime: replicatedTime.AddDuration(-2 * time.Hour),
+			expErr:      "is 2h0m0s behind the replicated time",
+		},
+		{
+			name:          "far-behind-allowed",
+			cutoverTime:   replicatedTime.AddDuration(-2 * time.Hour),
+			allowDataLoss: true,
+		},
+		{
+			name:        "far-behind-disabled",
+			cutoverTime: replicatedTime.AddDuration(-2 * time.Hour),
+			disabled:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			threshold := time.Duration(threshold)
+			if tc.disabled {
+				threshold = 0
+			}
+			err := validateCutoverDataLoss(tc.cutoverTime, replicatedTime, threshold, tc.allowDataLoss)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expErr)
+				require.Contains(t, errors.FlattenHints(err), "WITH ALLOW_DATA_LOSS")
+				require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+			}
+		})
+	}
+}
+
+func TestCheckNoCutoverInProgress(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const jobID = jobspb.JobID(123)
+	activeCutover := hlc.Timestamp{WallTime: 100}
+	remaining := roachpb.Spans{{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}}
+	for _, tc := range []struct {
+		name        string
+		progress    jobspb.StreamIngestionProgress
+		cutoverTime hlc.Timestamp
+		expErr      string
+	}{
+		{
+			name: "pending-cutover",
+			progress: jobspb.StreamIngestionProgress{
+				ReplicationStatus:     jobspb.ReplicationPendingCutover,
+				CutoverTime:           activeCutover,
+				RemainingCutoverSpans: remaining,
+			},
+			cutoverTime: hlc.Timestamp{WallTime: 200},
+		},
+		{
+			name: "active-cutover-at-different-time",
+			progress: jobspb.StreamIngestionProgress{
+				ReplicationStatus:     jobspb.ReplicationCuttingOver,
+				CutoverTime:           activeCutover,
+				RemainingCutoverSpans: remaining,
+			},
+			cutoverTime: hlc.Timestamp{WallTime: 200},
+			expErr:      "has 1 spans left to revert; cannot change the cutover time to 0.000000200,0",
+		},
+		{
+			name: "active-cutover-at-same-time",
+			progress: jobspb.StreamIngestionProgress{
+				ReplicationStatus:     jobspb.ReplicationCuttingOver,
+				CutoverTime:           activeCutover,
+				RemainingCutoverSpans: remaining,
+			},
+			cutoverTime: activeCutover,
+			expErr:      "job 123 already started cutting over to timestamp 0.000000100,0",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkNoCutoverInProgress(jobID, &tc.progress, tc.cutoverTime)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expErr)
+				require.Contains(t, errors.FlattenHints(err), "wait for the cutover in progress to complete")
+			}
+		})
+	}
+}
+
+// recordingNoticeSender is an eval.ClientNoticeSender that records every
+// notice it is sent.
+type recordingNoticeSender struct {
+	notices []pgnotice.Notice
+}
+
+var _ eval.ClientNoticeSender = (*recordingNoticeSender)(nil)
+
+// BufferClientNotice implements the eval.ClientNoticeSender interface.
+func (r *recordingNoticeSender) BufferClientNotice(_ context.Context, notice pgnotice.Notice) {
+	r.notices = append(r.notices, notice)
+}
+
+// SendClientNotice implements the eval.ClientNoticeSender interface.
+func (r *recordingNoticeSender) SendClientNotice(_ context.Context, notice pgnotice.Notice) error {
+	r.notices = append(r.notices, notice)
+	return nil
+}
+
+// TestValidateInitialScanComplete verifies that a job can only be cut over once
+// its initial scan has completed.
+func TestValidateInitialScanComplete(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tenantName := roachpb.TenantName("destination")
+	spanA := roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("b")}
+	spanB := roachpb.Span{Key: roachpb.Key("b"), EndKey: roachpb.Key("c")}
+	checkpoint := func(tsA, tsB hlc.Timestamp) jobspb.StreamIngestionCheckpoint {
+		return jobspb.StreamIngestionCheckpoint{ResolvedSpans: []jobspb.ResolvedSpan{
+			{Span: spanA, Timestamp: tsA},
+			{Span: spanB, Timestamp: tsB},
+		}}
+	}
+	scanned := hlc.Timestamp{WallTime: 100}
+
+	for _, tc := range []struct {
+		name     string
+		progress jobspb.StreamIngestionProgress
+		complete bool
+	}{
+		{name: "not-started", progress: jobspb.StreamIngestionProgress{}},
+		{
+			name:     "partially-scanned",
+			progress: jobspb.StreamIngestionProgress{Checkpoint: checkpoint(scanned, hlc.Timestamp{})},
+		},
+		{
+			name:     "fully-scanned",
+			progress: jobspb.StreamIngestionProgress{Checkpoint: checkpoint(scanned, scanned)},
+			complete: true,
+		},
+		{
+			name:     "replicated-time",
+			progress: jobspb.StreamIngestionProgress{ReplicatedTime: scanned},
+			complete: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateInitialScanComplete(tenantName, &tc.progress)
+			if tc.complete {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err,
+				`cannot complete replication of virtual cluster "destination": its initial scan has not completed`)
+			require.Equal(t, pgcode.ObjectNotInPrerequisiteState, pgerror.GetPGCode(err))
+			require.Contains(t, errors.FlattenHints(err), "wait for the initial scan to complete")
+		})
+	}
+}
+
+func TestLatestCutoverTime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	startTime := hlc.Timestamp{WallTime: 100}
+	tenantName := roachpb.TenantName("destination")
+
+	t.Run("frontier-advanced", func(t *testing.T) {
+		replicatedTime := hlc.Timestamp{WallTime: 200}
+		cutoverTime, notice := latestCutoverTime(tenantName, replicatedTime, startTime)
+		require.Equal(t, replicatedTime, cutoverTime)
+		require.Nil(t, notice)
+	})
+
+	t.Run("empty-frontier", func(t *testing.T) {
+		cutoverTime, notice := latestCutoverTime(tenantName, hlc.Timestamp{}, startTime)
+		require.Equal(t, startTime, cutoverTime)
+		require.NotNil(t, notice)
+		require.Contains(t, notice.Error(), `replicated time of virtual cluster "destination" has not advanced yet`)
+		require.Contains(t, notice.Error(), "cutting over to the replication start time")
+	})
+}
+
+func TestValidateResumePartitions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	now := hlc.Timestamp{WallTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()}
+	const retentionTTLSeconds = 60 * 60
+
+	require.NoError(t, validateResumePartitions(map[string]hlc.Timestamp{
+		"1": now,
+		"2": now.AddDuration(-time.Hour),
+	}, now, retentionTTLSeconds))
+
+	require.ErrorContains(t, validateResumePartitions(map[string]hlc.Timestamp{
+		"1": now,
+		"2": now.AddDuration(-2 * time.Hour),
+	}, now, retentionTTLSeconds), `partition "2" is older than the retention window`)
+
+	require.ErrorContains(t, validateResumePartitions(map[string]hlc.Timestamp{
+		"1": now.AddDuration(time.Minute),
+	}, now, retentionTTLSeconds), `partition "1" is in the future`)
+}
+
+// fixedPTSStorage is a protectedts.Storage that serves GetRecord from a fixed
+// set of records.
+type fixedPTSStorage struct {
+	protectedts.Storage
+	records map[uuid.UUID]*ptpb.Record
+}
+
+func (s *fixedPTSStorage) GetRecord(_ context.Context, id uuid.UUID) (*ptpb.Record, error) {
+	record, ok := s.records[id]
+	if !ok {
+		return nil, protectedts.ErrNotExists
+	}
+	return record, nil
+}
+
+func TestValidateResumePoint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const jobID = jobspb.JobID(42)
+	startTime := hlc.Timestamp{WallTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()}
+	replicatedTime := startTime.AddDuration(time.Hour)
+	ptsID := uuid.MakeV4()
+
+	details := jobspb.StreamIngestionDetails{
+		ReplicationStartTime:       startTime,
+		ProtectedTimestampRecordID: &ptsID,
+	}
+	progressAt := func(ts hlc.Timestamp) *jobspb.Progress {
+		return &jobspb.Progress{Details: &jobspb.Progress_StreamIngest{
+			StreamIngest: &jobspb.StreamIngestionProgress{ReplicatedTime: ts},
+		}}
+	}
+	storageAt := func(ts hlc.Timestamp) *fixedPTSStorage {
+		return &fixedPTSStorage{records: map[uuid.UUID]*ptpb.Record{ptsID: {ID: ptsID.GetBytesMut(), Timestamp: ts}}}
+	}
+
+	t.Run("checkpoint-retained", func(t *testing.T) {
+		require.NoError(t, validateResumePoint(ctx, storageAt(replicatedTime), jobID, details, progressAt(replicatedTime)))
+	})
+	t.Run("checkpoint-below-retained-floor", func(t *testing.T) {
+		err := validateResumePoint(ctx, storageAt(replicatedTime.AddDuration(time.Minute)), jobID, details, progressAt(replicatedTime))
+		require.ErrorContains(t, err, "cannot resume replication job 42: its resume point")
+		require.ErrorContains(t, err, "is below the earliest retained timestamp")
+		require.Contains(t, errors.FlattenHints(err), "may have been garbage collected")
+	})
+	t.Run("no-checkpoint-uses-start-time", func(t *testing.T) {
+		require.NoError(t, validateResumePoint(ctx, storageAt(startTime), jobID, details, progressAt(hlc.Timestamp{})))
+		require.ErrorContains(t,
+			validateResumePoint(ctx, storageAt(startTime.Next()), jobID, details, progressAt(hlc.Timestamp{})),
+			"is below the earliest retained timestamp")
+	})
+	t.Run("record-released", func(t *testing.T) {
+		err := validateResumePoint(ctx, &fixedPTSStorage{}, jobID, details, progressAt(replicatedTime))
+		require.ErrorContains(t, err, "protected timestamp record "+ptsID.String()+" no longer exists")
+	})
+	t.Run("no-record", func(t *testing.T) {
+		noRecord := details
+		noRecord.ProtectedTimestampRecordID = nil
+		require.NoError(t, validateResumePoint(ctx, &fixedPTSStorage{}, jobID, noRecord, progressAt(replicatedTime)))
+	})
+}
+
+func TestValidateTargetNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	known := map[roachpb.NodeID]bool{1: true, 2: true, 3: true}
+	getNodeDescriptor := func(nodeID roachpb.NodeID) (*roachpb.NodeDescriptor, error) {
+		if !known[nodeID] {
+			return nil, errors.Newf("unable to look up descriptor for n%d", nodeID)
+		}
+		return &roachpb.NodeDescriptor{NodeID: nodeID}, nil
+	}
+
+	require.NoError(t, validateTargetNodes([]roachpb.NodeID{1, 3}, getNodeDescriptor))
+	require.ErrorContains(t, validateTargetNodes([]roachpb.NodeID{1, 4}, getNodeDescriptor),
+		"invalid TARGET_NODES: unknown node 4")
+}
+
+// protectableFloorClient is a streamclient.Client that reports a fixed
+// earliest protectable timestamp.
+type protectableFloorClient struct {
+	streamclient.Client
+	floor, now hlc.Timestamp
+}
+
+func (c protectableFloorClient) EarliestProtectableTimestamp(
+	context.Context,
+) (hlc.Timestamp, hlc.Timestamp, error) {
+	return c.floor, c.now, nil
+}
+
+// blockingClient is a streamclient.Client whose PriorReplicationDetails blocks
+// until its context is canceled, and which records whether it was closed.
+type blockingClient struct {
+	streamclient.Client
+	closed atomic.Bool
+}
+
+func (c *blockingClient) PriorReplicationDetails(
+	ctx context.Context, _ roachpb.TenantName,
+) (string, string, hlc.Timestamp, error) {
+	<-ctx.Done()
+	return "", "", hlc.Timestamp{}, ctx.Err()
+}
+
+func (c *blockingClient) Close(context.Context) error {
+	c.closed.Store(true)
+	return nil
+}
+
+func TestFetchSourceReplicationDetailsTimeout(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const timeout = 10 * time.Millisecond
+
+	client := &blockingClient{}
+	_, _, _, err := fetchSourceReplicationDetails(ctx,
+		func(context.Context) (streamclient.Client, error) { return client, nil },
+		"source", 60*60, cluster.MakeTestingClusterSettings().Version, timeout)
+	require.ErrorContains(t, err, `operation "fetching prior replication details" timed out`)
+	require.True(t, client.closed.Load())
+
+	_, _, _, err = fetchSourceReplicationDetails(ctx,
+		func(ctx context.Context) (streamclient.Client, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+		"source", 60*60, cluster.MakeTestingClusterSettings().Version, timeout)
+	require.ErrorContains(t, err, `operation "creating stream client" timed out`)
+}
+
+func TestValidateRetentionAgainstSource(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	now := hlc.Timestamp{WallTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()}
+	const retentionTTLSeconds = 60 * 60
+
+	// No floor reported: nothing to validate against.
+	require.NoError(t, validateRetentionAgainstSource(ctx,
+		protectableFloorClient{now: now}, retentionTTLSeconds))
+
+	require.NoError(t, validateRetentionAgainstSource(ctx,
+		protectableFloorClient{floor: now.AddDuration(-2 * time.Hour), now: now}, retentionTTLSeconds))
+
+	floor := now.AddDuration(-time.Minute)
+	err := validateRetentionAgainstSource(ctx,
+		protectableFloorClient{floor: floor, now: now}, retentionTTLSeconds)
+	require.ErrorContains(t, err, "retention of 1h0m0s would require the source to protect data")
+	require.ErrorContains(t, err, fmt.Sprintf("its earliest protectable timestamp is %s", floor))
+	require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+}
+
+// versionClient is a streamclient.Client that reports a fixed cluster version.
+type versionClient struct {
+	streamclient.Client
+	version roachpb.Version
+}
+
+func (c versionClient) ClusterVersion(context.Context) (roachpb.Version, error) {
+	return c.version, nil
+}
+
+func TestValidateSourceVersion(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	destVersion := cluster.MakeTestingClusterSettings().Version
+	destActive := destVersion.ActiveVersion(ctx).Version
+
+	// No version reported: nothing to validate against.
+	require.NoError(t, validateSourceVersion(ctx, versionClient{}, destVersion))
+
+	require.NoError(t, validateSourceVersion(ctx, versionClient{version: destActive}, destVersion))
+
+	newer := roachpb.Version{Major: destActive.Major + 1000}
+	err := validateSourceVersion(ctx, versionClient{version: newer}, destVersion)
+	require.ErrorContains(t, err, fmt.Sprintf(
+		"source cluster version %s is newer than destination cluster version %s", newer, destActive))
+
+	older := roachpb.Version{Major: 1}
+	err = validateSourceVersion(ctx, versionClient{version: older}, destVersion)
+	require.ErrorContains(t, err, fmt.Sprintf("source cluster version %s is older than", older))
+}
+
+// licenseClient is a streamclient.Client that reports a fixed license
+// expiration, or fails to fetch it with err.
+type licenseClient struct {
+	streamclient.Client
+	expiration time.Time
+	err        error
+}
+
+func (c licenseClient) LicenseExpiration(context.Context) (time.Time, error) {
+	return c.expiration, c.err
+}
+
+func TestValidateSourceLicense(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name       string
+		expiration time.Time
+		expected   string
+	}{
+		{name: "unknown"},
+		{name: "valid", expiration: now.Add(30 * 24 * time.Hour)},
+		{name: "expiring", expiration: now.Add(24 * time.Hour),
+			expected: "the source cluster's license expires at 2024-01-02 00:00:00 +0000 UTC"},
+		{name: "expired", expiration: now.Add(-time.Hour),
+			expected: "the source cluster's license expired at 2023-12-31 23:00:00 +0000 UTC"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := licenseClient{expiration: tc.expiration}
+
+			// By default, an expiring license only warns.
+			notices := &recordingNoticeSender{}
+			require.NoError(t, validateSourceLicense(ctx, client, notices, now, false /* strict */))
+			if tc.expected == "" {
+				require.Empty(t, notices.notices)
+			} else {
+				require.Len(t, notices.notices, 1)
+				require.Equal(t, tc.expected, notices.notices[0].Error())
+			}
+
+			notices = &recordingNoticeSender{}
+			err := validateSourceLicense(ctx, client, notices, now, true /* strict */)
+			require.Empty(t, notices.notices)
+			if tc.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+
+	// Failing to fetch the license, e.g. for lack of privileges on the source,
+	// only warns unless strict.
+	client := licenseClient{err: errors.New("user lacks privilege")}
+	notices := &recordingNoticeSender{}
+	require.NoError(t, validateSourceLicense(ctx, client, notices, now, false /* strict */))
+	require.Len(t, notices.notices, 1)
+	require.Equal(t, "could not determine the source cluster's license expiration: user lacks privilege",
+		notices.notices[0].Error())
+	require.ErrorContains(t,
+		validateSourceLicense(ctx, client, &recordingNoticeSender{}, now, true /* strict */),
+		"fetching source cluster license: user lacks privilege")
+}
+
+func TestValidateCutoverTimeClockSkewTolerance(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	retained := hlc.Timestamp{WallTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()}
+	ptsID := uuid.MakeV4()
+	ptp := &fixedPTSStorage{records: map[uuid.UUID]*ptpb.Record{
+		ptsID: {ID: ptsID.GetBytesMut(), Timestamp: retained},
+	}}
+	const tolerance = 500 * time.Millisecond
+
+	// Without a tolerance, any cutover time below the retained timestamp fails.
+	require.NoError(t, validateCutoverTime(ctx, ptp, ptsID, retained, 0))
+	require.ErrorContains(t,
+		validateCutoverTime(ctx, ptp, ptsID, retained.AddDuration(-time.Millisecond), 0),
+		"is before earliest safe cutover time")
+
+	// With one, cutover times up to the tolerance below it are accepted.
+	require.NoError(t, validateCutoverTime(ctx, ptp, ptsID, retained.AddDuration(-tolerance), tolerance))
+	require.NoError(t, validateCutoverTime(ctx, ptp, ptsID, retained.AddDuration(-tolerance+time.Millisecond), tolerance))
+	err := validateCutoverTime(ctx, ptp, ptsID, retained.AddDuration(-tolerance-time.Millisecond), tolerance)
+	require.ErrorContains(t, err, "even with a clock skew tolerance of 500ms")
+	require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+}
+
+func TestEvalClockSkewTolerance(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	defer evalCtx.Stop(ctx)
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	exprEval := exprutil.MakeEvaluator("test", &semaCtx, &evalCtx)
+
+	for _, tc := range []struct {
+		tolerance string
+		expected  time.Duration
+		err       string
+	}{
+		{tolerance: "0s", expected: 0},
+		{tolerance: "500ms", expected: 500 * time.Millisecond},
+		{tolerance: "10s", expected: maxClockSkewTolerance},
+		{tolerance: "-1ms", err: "CLOCK_SKEW_TOLERANCE should be between 0s and 10s"},
+		{tolerance: "11s", err: "CLOCK_SKEW_TOLERANCE should be between 0s and 10s"},
+		{tolerance: "1 day", err: "CLOCK_SKEW_TOLERANCE should be between 0s and 10s"},
+	} {
+		t.Run(tc.tolerance, func(t *testing.T) {
+			tolerance, err := evalClockSkewTolerance(ctx, exprEval, tree.NewStrVal(tc.tolerance))
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tolerance)
+		})
+	}
+}
+
+func TestValidateCutoverTarget(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	require.NoError(t, validateCutoverTarget(&tree.ReplicationCutoverTime{Latest: true}))
+	require.NoError(t, validateCutoverTarget(&tree.ReplicationCutoverTime{
+		Timestamp: tree.NewStrVal("2030-01-01 00:00:00"),
+	}))
+
+	err := validateCutoverTarget(&tree.ReplicationCutoverTime{
+		Latest:    true,
+		Timestamp: tree.NewStrVal("2030-01-01 00:00:00"),
+	})
+	require.ErrorContains(t, err, "cannot specify both LATEST and an explicit cutover timestamp")
+	require.Equal(t, pgcode.Syntax, pgerror.GetPGCode(err))
+}
+
+func TestCutoverJSONDatum(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	require.Equal(t, alterReplicationCutoverHeader, cutoverResultHeader(tree.ReplicationCutoverOptions{}))
+	require.Equal(t, alterReplicationCutoverHeader,
+		cutoverResultHeader(tree.ReplicationCutoverOptions{Format: "DECIMAL"}))
+	require.Equal(t, alterReplicationCutoverJSONHeader,
+		cutoverResultHeader(tree.ReplicationCutoverOptions{Format: cutoverFormatJSON}))
+
+	tenInfo := &mtinfopb.TenantInfo{
+		SQLInfo:                          mtinfopb.SQLInfo{ID: 5, Name: "dest"},
+		PhysicalReplicationConsumerJobID: 7,
+	}
+	cutoverTime := hlc.Timestamp{WallTime: 42, Logical: 1}
+	d := cutoverJSONDatum(cutoverTime, true /* latest */, tenInfo)
+	require.Equal(t,
+		`{"cutover_time": 42.0000000001, "job_id": 7, "latest": true, "tenant_id": 5}`,
+		tree.MustBeDJSON(d).JSON.String())
+
+	// The cutover time has the same value as the default, decimal, result.
+	decimal := eval.TimestampToDecimalDatum(cutoverTime)
+	cutoverTimeJSON, err := tree.MustBeDJSON(d).JSON.FetchValKey("cutover_time")
+	require.NoError(t, err)
+	require.Equal(t, decimal.String(), cutoverTimeJSON.String())
+}
+
+func TestReplicationDestinationTenantID(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	id, err := replicationDestinationTenantID(&mtinfopb.TenantInfo{
+		SQLInfo: mtinfopb.SQLInfo{ID: 5, Name: "dest"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, roachpb.MustMakeTenantID(5), id)
+
+	for _, tc := range []struct {
+		id  uint64
+		exp string
+	}{
+		{id: 0, exp: `virtual cluster "dest" (0) cannot be a replication destination: invalid tenant ID 0`},
+		{id: 1, exp: `virtual cluster "dest" (1) cannot be a replication destination: it is the system virtual cluster`},
+	} {
+		_, err := replicationDestinationTenantID(&mtinfopb.TenantInfo{
+			SQLInfo: mtinfopb.SQLInfo{ID: tc.id, Name: "dest"},
+		})
+		require.EqualError(t, err, tc.exp)
+		require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+	}
+}
+
+// priorDetailsClient is a streamclient.Client that reports a fixed history ID
+// for the source tenant.
+type priorDetailsClient struct {
+	streamclient.Client
+	srcID string
+}
+
+func (c priorDetailsClient) PriorReplicationDetails(
+	context.Context, roachpb.TenantName,
+) (string, string, hlc.Timestamp, error) {
+	return c.srcID, "", hlc.Timestamp{}, nil
+}
+
+func (c priorDetailsClient) Close(context.Context) error {
+	return nil
+}
+
+func TestTestReplicationSource(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const timeout = time.Second
+	clusterID := uuid.MakeV4()
+	details := jobspb.StreamIngestionDetails{
+		SourceTenantName: "source",
+		SourceTenantID:   roachpb.MustMakeTenantID(5),
+		SourceClusterID:  clusterID,
+	}
+	expectedID := fmt.Sprintf("%s:%s", clusterID, roachpb.MustMakeTenantID(5))
+	reachable := func(srcID string) func(context.Context) (streamclient.Client, error) {
+		return func(context.Context) (streamclient.Client, error) {
+			return priorDetailsClient{srcID: srcID}, nil
+		}
+	}
+
+	t.Run("reachable", func(t *testing.T) {
+		row := testReplicationSource(ctx, reachable(expectedID), details, timeout)
+		require.Len(t, row, len(alterReplicationTestSourceHeader))
+		require.Equal(t, tree.Datums{
+			tree.NewDString(replicationSourceReachable), tree.NewDString(expectedID), tree.DNull,
+		}, row)
+	})
+
+	t.Run("mismatched", func(t *testing.T) {
+		otherID := fmt.Sprintf("%s:%s", uuid.MakeV4(), roachpb.MustMakeTenantID(5))
+		row := testReplicationSource(ctx, reachable(otherID), details, timeout)
+		require.Equal(t, tree.NewDString(replicationSourceMismatched), row[0])
+		require.Equal(t, tree.NewDString(otherID), row[1])
+		require.Contains(t, string(tree.MustBeDString(row[2])), "but the job replicates "+expectedID)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		row := testReplicationSource(ctx, func(context.Context) (streamclient.Client, error) {
+			return nil, errors.New("connection refused")
+		}, details, timeout)
+		require.Equal(t, tree.NewDString(replicationSourceUnreachable), row[0])
+		require.Equal(t, tree.DNull, row[1])
+		require.Contains(t, string(tree.MustBeDString(row[2])), "connection refused")
+
+		// A source that hangs is reported as unreachable once the timeout
+		// elapses, and the client is still closed.
+		client := &blockingClient{}
+		row = testReplicationSource(ctx, func(context.Context) (streamclient.Client, error) {
+			return client, nil
+		}, details, 10*time.Millisecond)
+		require.Equal(t, tree.NewDString(replicationSourceUnreachable), row[0])
+		require.Contains(t, string(tree.MustBeDString(row[2])), `operation "fetching prior replication details" timed out`)
+		require.True(t, client.closed.Load())
+	})
+}
+
+func TestValidateRetentionReduction(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	now := hlc.Timestamp{WallTime: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).UnixNano()}
+	frontier := now.AddDuration(-2 * time.Hour)
+	const day, hour, threeHours = 24 * 60 * 60, 60 * 60, 3 * 60 * 60
+
+	// Reducing the retention below the age of the frontier is rejected.
+	err := validateRetentionReduction("dest", day, hour, frontier, now)
+	require.ErrorContains(t, err,
+		`cannot reduce the retention of virtual cluster "dest" to 1h0m0s: its replication frontier is already 2h0m0s old`)
+	require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+
+	// Reducing it to a retention that still covers the frontier is allowed.
+	require.NoError(t, validateRetentionReduction("dest", day, threeHours, frontier, now))
+
+	// Increasing it, or leaving it unchanged, is always allowed.
+	require.NoError(t, validateRetentionReduction("dest", hour, threeHours, now.AddDuration(-day*time.Second), now))
+	require.NoError(t, validateRetentionReduction("dest", hour, hour, frontier, now))
 }
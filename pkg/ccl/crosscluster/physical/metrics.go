@@ -12,7 +12,11 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/metric/aggmetric"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 )
 
 const (
@@ -99,6 +103,12 @@ var (
 		Measurement: "Seconds",
 		Unit:        metric.Unit_SECONDS,
 	}
+	metaReplicatedTimeLagSeconds = metric.Metadata{
+		Name:        "physical_replication.replicated_time_lag_seconds",
+		Help:        "The difference between the current time and the replicated time of the physical replication stream into each virtual cluster",
+		Measurement: "Seconds",
+		Unit:        metric.Unit_SECONDS,
+	}
 	metaJobProgressUpdates = metric.Metadata{
 		Name:        "physical_replication.job_progress_updates",
 		Help:        "Total number of updates to the ingestion job progress",
@@ -122,6 +132,12 @@ var (
 		Measurement: "Events",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaOptionChanges = metric.Metadata{
+		Name:        "physical_replication.option_changes",
+		Help:        "Total number of times each option of a replication job was altered",
+		Measurement: "Option Changes",
+		Unit:        metric.Unit_COUNT,
+	}
 )
 
 // Metrics are for production monitoring of stream ingestion jobs.
@@ -141,6 +157,22 @@ type Metrics struct {
 	LatestDataCheckpointSpan   *metric.Gauge
 	ReplicatedTimeSeconds      *metric.Gauge
 	ReplicationCutoverProgress *metric.Gauge
+	ReplicatedTimeLagSeconds   *aggmetric.AggGauge
+	OptionChanges              *aggmetric.AggCounter
+
+	// tenantLag holds the child of ReplicatedTimeLagSeconds of each destination
+	// tenant whose replication job has recorded progress on this node.
+	tenantLag struct {
+		syncutil.Mutex
+		gauges map[roachpb.TenantID]*aggmetric.Gauge
+	}
+
+	// optionChanges holds the child of OptionChanges of each replication option
+	// that has been altered through this node.
+	optionChanges struct {
+		syncutil.Mutex
+		counters map[string]*aggmetric.Counter
+	}
 }
 
 // MetricStruct implements the metric.Struct interface.
@@ -182,10 +214,56 @@ func MakeMetrics(histogramWindow time.Duration) metric.Struct {
 		LatestDataCheckpointSpan:   metric.NewGauge(metaLatestDataCheckpointSpan),
 		ReplicatedTimeSeconds:      metric.NewGauge(metaReplicatedTimeSeconds),
 		ReplicationCutoverProgress: metric.NewGauge(metaReplicationCutoverProgress),
+		ReplicatedTimeLagSeconds:   aggmetric.NewGauge(metaReplicatedTimeLagSeconds, "tenant"),
+		OptionChanges:              aggmetric.NewCounter(metaOptionChanges, "option"),
 	}
+	m.tenantLag.gauges = make(map[roachpb.TenantID]*aggmetric.Gauge)
+	m.optionChanges.counters = make(map[string]*aggmetric.Counter)
 	return m
 }
 
+// recordOptionChange counts an alteration of the given replication option,
+// e.g. "RETENTION", that changed its value.
+func (m *Metrics) recordOptionChange(option string) {
+	m.optionChanges.Lock()
+	defer m.optionChanges.Unlock()
+	c, ok := m.optionChanges.counters[option]
+	if !ok {
+		c = m.OptionChanges.AddChild(option)
+		m.optionChanges.counters[option] = c
+	}
+	c.Inc(1)
+}
+
+// updateReplicatedTimeLag records how far the replicated time of the given
+// destination tenant lags behind now.
+func (m *Metrics) updateReplicatedTimeLag(
+	tenantID roachpb.TenantID, replicatedTime hlc.Timestamp, now time.Time,
+) {
+	m.tenantLag.Lock()
+	defer m.tenantLag.Unlock()
+	g, ok := m.tenantLag.gauges[tenantID]
+	if !ok {
+		g = m.ReplicatedTimeLagSeconds.AddChild(tenantID.String())
+		m.tenantLag.gauges[tenantID] = g
+	}
+	g.Update(int64(now.Sub(replicatedTime.GoTime()).Seconds()))
+}
+
+// removeReplicatedTimeLag stops reporting the replicated time lag of the given
+// destination tenant, e.g. once replication into it has stopped.
+func (m *Metrics) removeReplicatedTimeLag(tenantID roachpb.TenantID) {
+	m.tenantLag.Lock()
+	defer m.tenantLag.Unlock()
+	g, ok := m.tenantLag.gauges[tenantID]
+	if !ok {
+		return
+	}
+	g.Update(0)
+	g.Unlink()
+	delete(m.tenantLag.gauges, tenantID)
+}
+
 func init() {
 	jobs.MakeStreamIngestMetricsHook = MakeMetrics
 }
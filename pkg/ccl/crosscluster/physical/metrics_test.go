@@ -0,0 +1,60 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package physical
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicatedTimeLagMetric(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	m := MakeMetrics(time.Minute).(*Metrics)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tenantA, tenantB := roachpb.MustMakeTenantID(10), roachpb.MustMakeTenantID(11)
+	replicatedAt := func(lag time.Duration) hlc.Timestamp {
+		return hlc.Timestamp{WallTime: now.Add(-lag).UnixNano()}
+	}
+	lagOf := func(tenantID roachpb.TenantID) int64 {
+		m.tenantLag.Lock()
+		defer m.tenantLag.Unlock()
+		g, ok := m.tenantLag.gauges[tenantID]
+		require.True(t, ok, "no lag gauge for tenant %s", tenantID)
+		return g.Value()
+	}
+
+	m.updateReplicatedTimeLag(tenantA, replicatedAt(90*time.Second), now)
+	require.Equal(t, int64(90), lagOf(tenantA))
+
+	// A later progress update replaces the tenant's lag rather than adding to it.
+	m.updateReplicatedTimeLag(tenantA, replicatedAt(30*time.Second), now)
+	require.Equal(t, int64(30), lagOf(tenantA))
+
+	m.updateReplicatedTimeLag(tenantB, replicatedAt(time.Hour), now)
+	require.Equal(t, int64(3600), lagOf(tenantB))
+	require.Equal(t, int64(30), lagOf(tenantA))
+	require.Equal(t, int64(3630), m.ReplicatedTimeLagSeconds.Value())
+
+	m.removeReplicatedTimeLag(tenantB)
+	require.Equal(t, int64(30), m.ReplicatedTimeLagSeconds.Value())
+	m.tenantLag.Lock()
+	require.NotContains(t, m.tenantLag.gauges, tenantB)
+	m.tenantLag.Unlock()
+
+	// Removing a tenant without a gauge is a no-op.
+	m.removeReplicatedTimeLag(tenantB)
+}
@@ -0,0 +1,759 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package physical
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster"
+	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster/replicationutils"
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfo"
+	"github.com/cockroachdb/cockroach/pkg/multitenant/mtinfopb"
+	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+const showReplicationOp = "SHOW REPLICATION"
+
+var showReplicationStatsHeader = colinfo.ResultColumns{
+	{Name: "job_id", Typ: types.Int},
+	{Name: "replication_status", Typ: types.String},
+	{Name: "replicated_time", Typ: types.TimestampTZ},
+	{Name: "replication_lag", Typ: types.Interval},
+	{Name: "earliest_checkpointed_time", Typ: types.TimestampTZ},
+	{Name: "latest_checkpointed_time", Typ: types.TimestampTZ},
+	{Name: "slowest_fastest_ingestion_lag", Typ: types.Interval},
+	{Name: "protected_timestamp_record_id", Typ: types.Uuid},
+	{Name: "cutover_time", Typ: types.Decimal},
+	{Name: "initial_scan_pct", Typ: types.Float},
+}
+
+var showReplicationClockHeader = colinfo.ResultColumns{
+	{Name: "replicated_time", Typ: types.TimestampTZ},
+	{Name: "earliest_cutover_time", Typ: types.TimestampTZ},
+	{Name: "retained_ttl", Typ: types.Interval},
+}
+
+var showReplicationSettingsHeader = colinfo.ResultColumns{
+	{Name: "variable", Typ: types.String},
+	{Name: "value", Typ: types.String},
+}
+
+var showReplicationOptionsHeader = colinfo.ResultColumns{
+	{Name: "option", Typ: types.String},
+	{Name: "value", Typ: types.String},
+}
+
+var showReplicationPTSHeader = colinfo.ResultColumns{
+	{Name: "protected_timestamp_record_id", Typ: types.Uuid},
+	{Name: "protected_timestamp", Typ: types.Decimal},
+}
+
+var showReplicationThroughputHeader = colinfo.ResultColumns{
+	{Name: "ingested_bytes_per_second", Typ: types.Float},
+}
+
+var showReplicationCapacityHeader = colinfo.ResultColumns{
+	{Name: "max_active_jobs", Typ: types.Int},
+	{Name: "active_jobs", Typ: types.Int},
+	{Name: "remaining_jobs", Typ: types.Int},
+}
+
+var showReplicationTopologyHeader = colinfo.ResultColumns{
+	{Name: "partition_id", Typ: types.String},
+	{Name: "spans", Typ: types.String},
+	{Name: "source_instance_id", Typ: types.Int},
+	{Name: "destination_instance_id", Typ: types.Int},
+	{Name: "frontier", Typ: types.TimestampTZ},
+}
+
+// throughputSampleInterval is how far apart SHOW REPLICATION THROUGHPUT samples
+// the bytes ingested by the replication job.
+var throughputSampleInterval = time.Second
+
+// showReplicationHeader returns the result columns of the given kind of SHOW
+// REPLICATION statement.
+func showReplicationHeader(kind tree.ShowTenantReplicationKind) (colinfo.ResultColumns, error) {
+	switch kind {
+	case tree.ShowReplicationStats:
+		return showReplicationStatsHeader, nil
+	case tree.ShowReplicationClock:
+		return showReplicationClockHeader, nil
+	case tree.ShowReplicationSettings:
+		return showReplicationSettingsHeader, nil
+	case tree.ShowReplicationOptions:
+		return showReplicationOptionsHeader, nil
+	case tree.ShowReplicationPTS:
+		return showReplicationPTSHeader, nil
+	case tree.ShowReplicationThroughput:
+		return showReplicationThroughputHeader, nil
+	case tree.ShowReplicationCapacity:
+		return showReplicationCapacityHeader, nil
+	case tree.ShowReplicationTopology:
+		return showReplicationTopologyHeader, nil
+	default:
+		return nil, errors.AssertionFailedf("unexpected SHOW REPLICATION kind %s", kind)
+	}
+}
+
+func showReplicationTypeCheck(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	showStmt, ok := stmt.(*tree.ShowTenantReplication)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(
+		ctx, showReplicationOp, p.SemaCtx(),
+		exprutil.TenantSpec{TenantSpec: showStmt.TenantSpec},
+	); err != nil {
+		return false, nil, err
+	}
+	header, err := showReplicationHeader(showStmt.Kind)
+	if err != nil {
+		return false, nil, err
+	}
+	return true, header, nil
+}
+
+func showReplicationHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, []sql.PlanNode, bool, error) {
+	showStmt, ok := stmt.(*tree.ShowTenantReplication)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	if !p.ExecCfg().Codec.ForSystemTenant() {
+		return nil, nil, nil, false, pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only the system tenant can show replication details")
+	}
+
+	header, err := showReplicationHeader(showStmt.Kind)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		if err := utilccl.CheckEnterpriseEnabled(
+			p.ExecCfg().Settings,
+			showReplicationOp,
+		); err != nil {
+			return err
+		}
+
+		if err := sql.CanManageTenant(ctx, p); err != nil {
+			return err
+		}
+
+		// SHOW REPLICATION CAPACITY is not specific to a virtual cluster.
+		if showStmt.Kind == tree.ShowReplicationCapacity {
+			row, err := showReplicationCapacity(ctx, p)
+			if err != nil {
+				return err
+			}
+			resultsCh <- row
+			return nil
+		}
+
+		tenInfo, err := p.LookupTenantInfo(ctx, showStmt.TenantSpec, showReplicationOp)
+		if err != nil {
+			return err
+		}
+		if err := checkForActiveIngestionJob(tenInfo); err != nil {
+			return err
+		}
+
+		job, err := p.ExecCfg().JobRegistry.LoadJobWithTxn(ctx,
+			tenInfo.PhysicalReplicationConsumerJobID, p.InternalSQLTxn())
+		if err != nil {
+			return err
+		}
+		details, ok := job.Details().(jobspb.StreamIngestionDetails)
+		if !ok {
+			return errors.Newf("job with id %d is not a stream ingestion job", job.ID())
+		}
+
+		var row tree.Datums
+		switch showStmt.Kind {
+		case tree.ShowReplicationStats:
+			row, err = showReplicationStats(ctx, job, details)
+		case tree.ShowReplicationClock:
+			row, err = showReplicationClock(ctx, p, job, details)
+		case tree.ShowReplicationPTS:
+			row, err = showReplicationPTS(ctx, p, tenInfo, details)
+		case tree.ShowReplicationThroughput:
+			row, err = showReplicationThroughput(ctx, p, job, details)
+		case tree.ShowReplicationSettings:
+			for _, row := range replicationSettingsDatums(details.SettingsSnapshot) {
+				resultsCh <- row
+			}
+			return nil
+		case tree.ShowReplicationOptions:
+			rows, err := replicationOptionsDatums(details)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				resultsCh <- row
+			}
+			return nil
+		case tree.ShowReplicationTopology:
+			rows, err := showReplicationTopology(ctx, p, job)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				resultsCh <- row
+			}
+			return nil
+		default:
+			err = errors.AssertionFailedf("unexpected SHOW REPLICATION kind %s", showStmt.Kind)
+		}
+		if err != nil {
+			return err
+		}
+		resultsCh <- row
+		return nil
+	}
+	return fn, header, nil, false, nil
+}
+
+// showReplicationStats returns a row of showReplicationStatsHeader describing
+// the ingestion statistics of the given consumer job.
+func showReplicationStats(
+	ctx context.Context, job *jobs.Job, details jobspb.StreamIngestionDetails,
+) (tree.Datums, error) {
+	stats, err := replicationutils.GetStreamIngestionStats(ctx, details, job.Progress())
+	if err != nil {
+		return nil, err
+	}
+	return replicationStatsDatums(job.ID(), stats), nil
+}
+
+// replicationStatsDatums renders the given ingestion statistics as a row of
+// showReplicationStatsHeader. Statistics that haven't been recorded yet are
+// rendered as NULL.
+func replicationStatsDatums(jobID jobspb.JobID, stats *streampb.StreamIngestionStats) tree.Datums {
+	replicationStatus := tree.DNull
+	cutoverTime := tree.DNull
+	initialScanPct := tree.DNull
+	if progress := stats.IngestionProgress; progress != nil {
+		replicationStatus = tree.NewDString(progress.ReplicationStatus.String())
+		if !progress.CutoverTime.IsEmpty() {
+			cutoverTime = eval.TimestampToDecimalDatum(progress.CutoverTime)
+		}
+		if pct, ok := initialScanProgress(progress); ok {
+			initialScanPct = tree.NewDFloat(tree.DFloat(pct))
+		}
+	}
+
+	replicatedTime := tree.DNull
+	replicationLag := tree.DNull
+	earliestCheckpointed := tree.DNull
+	latestCheckpointed := tree.DNull
+	slowestFastestLag := tree.DNull
+	if lagInfo := stats.ReplicationLagInfo; lagInfo != nil {
+		replicatedTime = timestampTZDatum(lagInfo.MinIngestedTimestamp)
+		replicationLag = intervalDatum(lagInfo.ReplicationLag)
+		// The checkpointed timestamps are only meaningful once at least one
+		// resolved span has been checkpointed.
+		if lagInfo.EarliestCheckpointedTimestamp.LessEq(lagInfo.LatestCheckpointedTimestamp) {
+			earliestCheckpointed = timestampTZDatum(lagInfo.EarliestCheckpointedTimestamp)
+			latestCheckpointed = timestampTZDatum(lagInfo.LatestCheckpointedTimestamp)
+			slowestFastestLag = intervalDatum(lagInfo.SlowestFastestIngestionLag)
+		}
+	}
+
+	ptsRecordID := tree.DNull
+	if id := stats.IngestionDetails.ProtectedTimestampRecordID; id != nil {
+		ptsRecordID = tree.NewDUuid(tree.DUuid{UUID: *id})
+	}
+
+	return tree.Datums{
+		tree.NewDInt(tree.DInt(jobID)),
+		replicationStatus,
+		replicatedTime,
+		replicationLag,
+		earliestCheckpointed,
+		latestCheckpointed,
+		slowestFastestLag,
+		ptsRecordID,
+		cutoverTime,
+		initialScanPct,
+	}
+}
+
+// initialScanProgress returns the percentage of the checkpointed spans of the
+// consumer job that have completed their initial scan, i.e. that have been
+// resolved at any time. Spans are weighed equally, regardless of how much data
+// they hold. It returns false once the job has a replicated time, as the
+// initial scan is then complete, or if no spans have been checkpointed yet.
+func initialScanProgress(progress *jobspb.StreamIngestionProgress) (float64, bool) {
+	resolvedSpans := progress.Checkpoint.ResolvedSpans
+	if !progress.ReplicatedTime.IsEmpty() || len(resolvedSpans) == 0 {
+		return 0, false
+	}
+	var scanned int
+	for _, resolvedSpan := range resolvedSpans {
+		if !resolvedSpan.Timestamp.IsEmpty() {
+			scanned++
+		}
+	}
+	return 100 * float64(scanned) / float64(len(resolvedSpans)), true
+}
+
+// showReplicationCapacity returns a row of showReplicationCapacityHeader
+// describing how many more replication consumer jobs the cluster can run.
+func showReplicationCapacity(ctx context.Context, p sql.PlanHookState) (tree.Datums, error) {
+	active, err := countActiveIngestionJobs(ctx, p.InternalSQLTxn())
+	if err != nil {
+		return nil, err
+	}
+	maxJobs := crosscluster.MaxActiveIngestionJobs.Get(&p.ExecCfg().Settings.SV)
+	return replicationCapacityDatums(maxJobs, active), nil
+}
+
+// replicationCapacityDatums renders the given limit and count of active
+// replication consumer jobs as a row of showReplicationCapacityHeader. The
+// limit and the remaining headroom are rendered as NULL if there is no limit.
+func replicationCapacityDatums(maxJobs int64, active int) tree.Datums {
+	if maxJobs == 0 {
+		return tree.Datums{tree.DNull, tree.NewDInt(tree.DInt(active)), tree.DNull}
+	}
+	// The limit may have been lowered below the number of jobs already active.
+	remaining := maxJobs - int64(active)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return tree.Datums{
+		tree.NewDInt(tree.DInt(maxJobs)),
+		tree.NewDInt(tree.DInt(active)),
+		tree.NewDInt(tree.DInt(remaining)),
+	}
+}
+
+// countActiveIngestionJobs returns the number of replication consumer jobs
+// active in the cluster, i.e. of virtual clusters being replicated into.
+func countActiveIngestionJobs(ctx context.Context, txn isql.Txn) (int, error) {
+	var arg interface{} = mtinfopb.DataStateAdd
+	rows, err := txn.QueryBufferedEx(ctx, "count-ingestion-jobs", txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT id, info FROM system.tenants WHERE data_state = $1`, arg)
+	if err != nil {
+		return 0, err
+	}
+	var active int
+	for _, row := range rows {
+		_, info, err := mtinfo.GetTenantInfoFromSQLRow(row)
+		if err != nil {
+			return 0, err
+		}
+		if info.PhysicalReplicationConsumerJobID != 0 {
+			active++
+		}
+	}
+	return active, nil
+}
+
+// showReplicationClock returns a row of showReplicationClockHeader describing
+// the replicated time of the given consumer job and the window of times it can
+// be cut over to.
+func showReplicationClock(
+	ctx context.Context, p sql.PlanHookState, job *jobs.Job, details jobspb.StreamIngestionDetails,
+) (tree.Datums, error) {
+	progress := job.Progress()
+	replicatedTime := replicationutils.ReplicatedTimeFromProgress(&progress)
+
+	// The earliest time the job can be cut over to is the timestamp protected by
+	// the job's PTS record, as data before it may have been garbage collected.
+	var earliestCutoverTime hlc.Timestamp
+	if details.ProtectedTimestampRecordID != nil {
+		pts := protectedTimestampStorage(p)
+		if pts == nil {
+			return nil, errors.New("protected timestamp subsystem unavailable")
+		}
+		record, err := pts.GetRecord(ctx, *details.ProtectedTimestampRecordID)
+		if err != nil {
+			return nil, err
+		}
+		earliestCutoverTime = record.Timestamp
+	}
+	return replicationClockDatums(replicatedTime, earliestCutoverTime, details.ReplicationTTLSeconds), nil
+}
+
+// replicationClockDatums renders the given times as a row of
+// showReplicationClockHeader. Times that haven't been recorded yet are rendered
+// as NULL.
+func replicationClockDatums(
+	replicatedTime, earliestCutoverTime hlc.Timestamp, retentionTTLSeconds int32,
+) tree.Datums {
+	return tree.Datums{
+		timestampTZDatum(replicatedTime),
+		timestampTZDatum(earliestCutoverTime),
+		intervalDatum(time.Duration(retentionTTLSeconds) * time.Second),
+	}
+}
+
+// showReplicationPTS returns a row of showReplicationPTSHeader describing the
+// protected timestamp record of the given consumer job, which retains the
+// destination tenant's history so that it can be cut over to past times.
+func showReplicationPTS(
+	ctx context.Context,
+	p sql.PlanHookState,
+	tenInfo *mtinfopb.TenantInfo,
+	details jobspb.StreamIngestionDetails,
+) (tree.Datums, error) {
+	if details.ProtectedTimestampRecordID == nil {
+		return nil, errors.Newf("replicated tenant %q (%d) has not yet recorded a retained timestamp",
+			tenInfo.Name, tenInfo.ID)
+	}
+	pts := protectedTimestampStorage(p)
+	if pts == nil {
+		return nil, errors.New("protected timestamp subsystem unavailable")
+	}
+	record, err := pts.GetRecord(ctx, *details.ProtectedTimestampRecordID)
+	if err != nil {
+		return nil, err
+	}
+	return replicationPTSDatums(record.ID.GetUUID(), record.Timestamp), nil
+}
+
+// replicationPTSDatums renders the given protected timestamp record as a row
+// of showReplicationPTSHeader.
+func replicationPTSDatums(recordID uuid.UUID, protected hlc.Timestamp) tree.Datums {
+	return tree.Datums{
+		tree.NewDUuid(tree.DUuid{UUID: recordID}),
+		eval.TimestampToDecimalDatum(protected),
+	}
+}
+
+// ingestionStatsSource returns the current ingestion statistics of a
+// replication job.
+type ingestionStatsSource func(context.Context) (*streampb.StreamIngestionStats, error)
+
+// showReplicationThroughput returns a row of showReplicationThroughputHeader
+// with the rate at which the given consumer job is ingesting data, or NULL if
+// it isn't actively ingesting.
+func showReplicationThroughput(
+	ctx context.Context, p sql.PlanHookState, job *jobs.Job, details jobspb.StreamIngestionDetails,
+) (tree.Datums, error) {
+	if job.Status() != jobs.StatusRunning {
+		return tree.Datums{tree.DNull}, nil
+	}
+	// The progress is loaded outside of the statement's transaction, so that
+	// the second sample observes the progress persisted since the first.
+	source := func(ctx context.Context) (*streampb.StreamIngestionStats, error) {
+		progress, err := jobs.LoadJobProgress(ctx, p.ExecCfg().InternalDB, job.ID())
+		if err != nil {
+			return nil, err
+		}
+		if progress == nil {
+			return nil, errors.Newf("job with id %d has no progress", job.ID())
+		}
+		return replicationutils.GetStreamIngestionStats(ctx, details, *progress)
+	}
+	rate, err := sampleReplicationThroughput(ctx, source, throughputSampleInterval, timeutil.Now)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Datums{rate}, nil
+}
+
+// sampleReplicationThroughput reads the cumulative bytes ingested by a
+// replication job twice, interval apart, and returns the rate at which they
+// advanced in bytes per second. NULL is returned if the job isn't replicating
+// at either sample. The cumulative bytes are only updated as the job persists
+// its progress, so the rate reflects the data ingested between checkpoints.
+func sampleReplicationThroughput(
+	ctx context.Context, source ingestionStatsSource, interval time.Duration, now func() time.Time,
+) (tree.Datum, error) {
+	first, err := source(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := now()
+	if !activelyIngesting(first) {
+		return tree.DNull, nil
+	}
+
+	select {
+	case <-time.After(interval):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	second, err := source(ctx)
+	if err != nil {
+		return nil, err
+	}
+	elapsed := now().Sub(start)
+	if !activelyIngesting(second) || elapsed <= 0 {
+		return tree.DNull, nil
+	}
+	ingested := second.IngestionProgress.IngestedBytes - first.IngestionProgress.IngestedBytes
+	return tree.NewDFloat(tree.DFloat(float64(ingested) / elapsed.Seconds())), nil
+}
+
+// showReplicationTopology returns a row of showReplicationTopologyHeader for
+// each producer partition tracked by the given consumer job.
+func showReplicationTopology(
+	ctx context.Context, p sql.PlanHookState, job *jobs.Job,
+) ([]tree.Datums, error) {
+	specBytes, err := jobs.ReadChunkedFileToJobInfo(ctx, replicationPartitionInfoFilename,
+		p.InternalSQLTxn(), job.ID())
+	if err != nil {
+		return nil, err
+	}
+	var partitionSpecs execinfrapb.StreamIngestionPartitionSpecs
+	if err := protoutil.Unmarshal(specBytes, &partitionSpecs); err != nil {
+		return nil, err
+	}
+	var checkpoint jobspb.StreamIngestionCheckpoint
+	if progress := job.Progress().GetStreamIngest(); progress != nil {
+		checkpoint = progress.Checkpoint
+	}
+	return replicationTopologyDatums(job.ID(), partitionSpecs, checkpoint)
+}
+
+// replicationTopologyDatums renders the given partitions as rows of
+// showReplicationTopologyHeader, ordered by partition ID. The frontier of a
+// partition is the earliest time any of its spans has been checkpointed at,
+// or NULL if some of them haven't been checkpointed yet. It returns an error
+// if the job hasn't persisted the partitions of its initial plan yet.
+func replicationTopologyDatums(
+	jobID jobspb.JobID,
+	partitionSpecs execinfrapb.StreamIngestionPartitionSpecs,
+	checkpoint jobspb.StreamIngestionCheckpoint,
+) ([]tree.Datums, error) {
+	if len(partitionSpecs.Specs) == 0 {
+		return nil, pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"replication job %d has not established a topology yet", jobID)
+	}
+	f, err := span.MakeFrontier()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Release()
+	for _, rs := range checkpoint.ResolvedSpans {
+		if err := f.AddSpansAt(rs.Timestamp, rs.Span); err != nil {
+			return nil, err
+		}
+	}
+
+	specs := slices.Clone(partitionSpecs.Specs)
+	sort.Slice(specs, func(i, j int) bool { return specs[i].PartitionID < specs[j].PartitionID })
+	rows := make([]tree.Datums, 0, len(specs))
+	for _, spec := range specs {
+		spans := make([]string, 0, len(spec.Spans))
+		var frontier hlc.Timestamp
+		checkpointed := true
+		for _, sp := range spec.Spans {
+			spans = append(spans, sp.String())
+			covered := false
+			f.SpanEntries(sp, func(_ roachpb.Span, ts hlc.Timestamp) span.OpResult {
+				covered = true
+				if ts.IsEmpty() {
+					checkpointed = false
+				} else if frontier.IsEmpty() || ts.Less(frontier) {
+					frontier = ts
+				}
+				return span.ContinueMatch
+			})
+			if !covered {
+				checkpointed = false
+			}
+		}
+		if !checkpointed {
+			frontier = hlc.Timestamp{}
+		}
+		rows = append(rows, tree.Datums{
+			tree.NewDString(spec.PartitionID),
+			tree.NewDString(strings.Join(spans, ", ")),
+			tree.NewDInt(tree.DInt(spec.SrcInstanceID)),
+			tree.NewDInt(tree.DInt(spec.DestInstanceID)),
+			timestampTZDatum(frontier),
+		})
+	}
+	return rows, nil
+}
+
+// activelyIngesting returns whether the given ingestion statistics describe a
+// job that is replicating data from the source.
+func activelyIngesting(stats *streampb.StreamIngestionStats) bool {
+	return stats.IngestionProgress != nil &&
+		stats.IngestionProgress.ReplicationStatus == jobspb.Replicating
+}
+
+// timestampTZDatum returns ts as a TIMESTAMPTZ datum, or NULL if ts is empty.
+// As in SHOW VIRTUAL CLUSTER, the timestamp is truncated, rather than rounded,
+// to the microsecond so that it is never ahead of ts.
+func timestampTZDatum(ts hlc.Timestamp) tree.Datum {
+	if ts.IsEmpty() {
+		return tree.DNull
+	}
+	d, err := tree.MakeDTimestampTZ(ts.GoTime().Truncate(time.Microsecond), time.Nanosecond)
+	if err != nil {
+		return tree.DNull
+	}
+	return d
+}
+
+func intervalDatum(d time.Duration) tree.Datum {
+	return tree.NewDInterval(duration.MakeDuration(d.Nanoseconds(), 0, 0), types.DefaultIntervalTypeMetadata)
+}
+
+func init() {
+	sql.AddPlanHook("show replication", showReplicationHook, showReplicationTypeCheck)
+}
+
+// replicationSettingsDatums renders the given snapshot of cluster settings as
+// rows of showReplicationSettingsHeader, ordered by setting name.
+func replicationSettingsDatums(snapshot map[string]string) []tree.Datums {
+	names := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rows := make([]tree.Datums, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, tree.Datums{tree.NewDString(name), tree.NewDString(snapshot[name])})
+	}
+	return rows
+}
+
+// replicationOptionsDatums renders the replication options stored in the
+// details of a consumer job as rows of showReplicationOptionsHeader. Options
+// that are unset, and so have no effect, are omitted, except for RETENTION and
+// VERIFY_CHECKSUMS, which always apply. Values are rendered such that they could
+// be passed back to the option they are listed under, except that credentials
+// in URIs, and the ENCRYPTION_KEY in its entirety, are redacted.
+func replicationOptionsDatums(details jobspb.StreamIngestionDetails) ([]tree.Datums, error) {
+	var rows []tree.Datums
+	add := func(option, value string) {
+		rows = append(rows, tree.Datums{tree.NewDString(option), tree.NewDString(value)})
+	}
+	seconds := func(s int32) string {
+		return (time.Duration(s) * time.Second).String()
+	}
+	addURI := func(option, uri string) error {
+		sanitized, err := cloud.SanitizeExternalStorageURI(uri, nil /* extraParams */)
+		if err != nil {
+			return errors.Wrapf(err, "sanitizing %s", option)
+		}
+		add(option, sanitized)
+		return nil
+	}
+
+	add("RETENTION", seconds(details.ReplicationTTLSeconds))
+	if details.InitialReplicationTTLSeconds != 0 {
+		add("INITIAL_RETENTION", seconds(details.InitialReplicationTTLSeconds))
+	}
+	if details.SeedFromBackup != "" {
+		if err := addURI("SEED_FROM_BACKUP", details.SeedFromBackup); err != nil {
+			return nil, err
+		}
+	}
+	if details.Priority != "" {
+		add("PRIORITY", details.Priority)
+	}
+	if len(details.ResumePartitions) > 0 {
+		partitions := make(map[string]string, len(details.ResumePartitions))
+		for id, ts := range details.ResumePartitions {
+			partitions[id] = ts.AsOfSystemTime()
+		}
+		encoded, err := json.Marshal(partitions)
+		if err != nil {
+			return nil, errors.Wrap(err, "encoding RESUME_PARTITIONS")
+		}
+		add("RESUME_PARTITIONS", string(encoded))
+	}
+	if details.Owner != "" {
+		add("OWNER", details.Owner)
+	}
+	if details.ServiceModeOnComplete != mtinfopb.ServiceModeNone {
+		add("SERVICE_MODE_ON_COMPLETE", details.ServiceModeOnComplete.String())
+	}
+	if details.PauseOnDiskFull != 0 {
+		add("PAUSE_ON_DISK_FULL", strconv.Itoa(int(details.PauseOnDiskFull)))
+	}
+	if details.MaxPartitionStreams != 0 {
+		add("MAX_PARTITION_STREAMS", strconv.Itoa(int(details.MaxPartitionStreams)))
+	}
+	if details.PTSAdvanceInterval != 0 {
+		add("PTS_ADVANCE_INTERVAL", details.PTSAdvanceInterval.String())
+	}
+	add("VERIFY_CHECKSUMS", strconv.FormatBool(details.VerifyChecksums))
+	if details.EventSink != "" {
+		if err := addURI("EVENT_SINK", details.EventSink); err != nil {
+			return nil, err
+		}
+	}
+	if len(details.TargetNodeIDs) > 0 {
+		nodes := make([]string, len(details.TargetNodeIDs))
+		for i, nodeID := range details.TargetNodeIDs {
+			nodes[i] = fmt.Sprint(nodeID)
+		}
+		add("TARGET_NODES", strings.Join(nodes, ","))
+	}
+	if details.OnError != "" {
+		add("ON_ERROR", details.OnError)
+	}
+	if !details.AutoCutoverTime.IsEmpty() {
+		add("AUTO_CUTOVER", details.AutoCutoverTime.AsOfSystemTime())
+	}
+	if details.IdleTimeout != 0 {
+		add("IDLE_TIMEOUT", details.IdleTimeout.String())
+	}
+	if details.SpanConfigMode != "" {
+		add("SPAN_CONFIG", details.SpanConfigMode)
+	}
+	if details.EncryptionKey != "" {
+		add("ENCRYPTION_KEY", strings.Trim(tree.PasswordSubstitution, "'"))
+	}
+	if details.CheckpointInterval != 0 {
+		add("CHECKPOINT_INTERVAL", details.CheckpointInterval.String())
+	}
+	if details.SourceConnPool != 0 {
+		add("SOURCE_CONN_POOL", strconv.Itoa(int(details.SourceConnPool)))
+	}
+	if details.IngestMode != "" {
+		add("INGEST_MODE", details.IngestMode)
+	}
+	return rows, nil
+}
@@ -11,6 +11,7 @@ package physical
 import (
 	"context"
 	"math"
+	"slices"
 	"sort"
 	"time"
 
@@ -64,7 +65,8 @@ func startDistIngestion(
 
 	// Start from the last checkpoint if it exists.
 	heartbeatTimestamp := replicationutils.ResolveHeartbeatTime(
-		replicatedTime, details.ReplicationStartTime, streamProgress.CutoverTime, details.ReplicationTTLSeconds)
+		replicatedTime, details.ReplicationStartTime, streamProgress.CutoverTime,
+		activeReplicationTTLSeconds(&details, streamProgress))
 	msg := redact.Sprintf("resuming stream (producer job %d) from %s", streamID, heartbeatTimestamp)
 
 	if streamProgress.InitialRevertRequired {
@@ -133,6 +135,7 @@ func startDistIngestion(
 				"attempted to persist an empty list of stream addresses"))
 		}
 		md.Progress.GetStreamIngest().StreamAddresses = planner.initialStreamAddresses
+		md.Progress.GetStreamIngest().RefreshTopology = false
 		ju.UpdateProgress(md.Progress)
 		return nil
 	})
@@ -183,6 +186,10 @@ func startDistIngestion(
 		if knobs := execCtx.ExecCfg().StreamingTestingKnobs; knobs != nil && knobs.SkipSpanConfigReplication {
 			return nil
 		}
+		if details.SpanConfigMode == spanConfigModeLocal {
+			log.Infof(ctx, "span config replication is disabled by SPAN_CONFIG = '%s'", spanConfigModeLocal)
+			return nil
+		}
 		sourceTenantID, err := planner.getSrcTenantID()
 		if err != nil {
 			return err
@@ -518,6 +525,10 @@ func (p *replicationFlowPlanner) constructPlanGenerator(
 		if err != nil {
 			return nil, nil, err
 		}
+		sqlInstanceIDs, err = confineToTargetNodes(sqlInstanceIDs, details.TargetNodeIDs)
+		if err != nil {
+			return nil, nil, err
+		}
 		if !p.createdInitialPlan() {
 			p.initialTopology = topology
 			p.initialStreamAddresses = topology.StreamAddresses()
@@ -536,6 +547,7 @@ func (p *replicationFlowPlanner) constructPlanGenerator(
 			initialScanTimestamp,
 			previousReplicatedTime,
 			checkpoint,
+			details.ResumePartitions,
 			ingestionJobID,
 			streamID,
 			topology.SourceTenantID,
@@ -543,6 +555,12 @@ func (p *replicationFlowPlanner) constructPlanGenerator(
 		if err != nil {
 			return nil, nil, err
 		}
+		streamIngestionFrontierSpec.CheckpointInterval = details.CheckpointInterval
+		for _, specs := range streamIngestionSpecs {
+			for i := range specs {
+				specs[i].IngestMode = details.IngestMode
+			}
+		}
 		if knobs := execCtx.ExecCfg().StreamingTestingKnobs; knobs != nil && knobs.AfterReplicationFlowPlan != nil {
 			knobs.AfterReplicationFlowPlan(streamIngestionSpecs, streamIngestionFrontierSpec)
 		}
@@ -713,6 +731,28 @@ func (nm *nodeMatcher) FindMatch(destIDCandidates []base.SQLInstanceID) base.SQL
 	return currentMatch
 }
 
+// confineToTargetNodes returns the instances, out of those available for
+// planning, that run on the nodes set by the TARGET_NODES option, or all of them
+// if the option is unset. An error is returned if none of the target nodes are
+// available.
+func confineToTargetNodes(
+	instanceIDs []base.SQLInstanceID, targetNodeIDs []roachpb.NodeID,
+) ([]base.SQLInstanceID, error) {
+	if len(targetNodeIDs) == 0 {
+		return instanceIDs, nil
+	}
+	var confined []base.SQLInstanceID
+	for _, id := range instanceIDs {
+		if slices.Contains(targetNodeIDs, roachpb.NodeID(id)) {
+			confined = append(confined, id)
+		}
+	}
+	if len(confined) == 0 {
+		return nil, errors.Newf("none of the target nodes %v are available for planning", targetNodeIDs)
+	}
+	return confined, nil
+}
+
 func GetDestNodeLocalities(
 	ctx context.Context, dsp *sql.DistSQLPlanner, instanceIDs []base.SQLInstanceID,
 ) ([]sql.InstanceLocality, error) {
@@ -736,6 +776,7 @@ func constructStreamIngestionPlanSpecs(
 	initialScanTimestamp hlc.Timestamp,
 	previousReplicatedTimestamp hlc.Timestamp,
 	checkpoint jobspb.StreamIngestionCheckpoint,
+	resumePartitions map[string]hlc.Timestamp,
 	jobID jobspb.JobID,
 	streamID streampb.StreamID,
 	sourceTenantID roachpb.TenantID,
@@ -785,6 +826,10 @@ func constructStreamIngestionPlanSpecs(
 				DestInstanceID:    destID,
 			},
 		}
+		if resumeTS, ok := resumePartitions[partition.ID]; ok {
+			log.Infof(ctx, "resuming partition %s from %s", partition.ID, resumeTS)
+			spec.Checkpoint = withResumedPartition(checkpoint, partition.Spans, resumeTS)
+		}
 		streamIngestionSpecs[destID] = append(streamIngestionSpecs[destID], spec)
 		spanGroup.Add(partition.Spans...)
 	}
@@ -809,6 +854,20 @@ func constructStreamIngestionPlanSpecs(
 	return streamIngestionSpecs, streamIngestionFrontierSpec, nil
 }
 
+// withResumedPartition returns a copy of checkpoint in which the spans of a
+// partition are additionally resolved at resumeTS, so that the ingestion
+// processor subscribes to the partition from at least that timestamp.
+func withResumedPartition(
+	checkpoint jobspb.StreamIngestionCheckpoint, spans []roachpb.Span, resumeTS hlc.Timestamp,
+) jobspb.StreamIngestionCheckpoint {
+	resolvedSpans := make([]jobspb.ResolvedSpan, 0, len(checkpoint.ResolvedSpans)+len(spans))
+	resolvedSpans = append(resolvedSpans, checkpoint.ResolvedSpans...)
+	for _, sp := range spans {
+		resolvedSpans = append(resolvedSpans, jobspb.ResolvedSpan{Span: sp, Timestamp: resumeTS})
+	}
+	return jobspb.StreamIngestionCheckpoint{ResolvedSpans: resolvedSpans}
+}
+
 // waitUntilProducerActive pings the producer job and waits until it
 // is active/running. It returns nil when the job is active.
 func waitUntilProducerActive(
@@ -330,6 +330,7 @@ func TestSourceDestMatching(t *testing.T) {
 				hlc.Timestamp{},
 				hlc.Timestamp{},
 				jobspb.StreamIngestionCheckpoint{},
+				nil, /* resumePartitions */
 				jobspb.InvalidJobID,
 				streampb.StreamID(2),
 				roachpb.TenantID{InternalValue: 2},
@@ -345,6 +346,55 @@ func TestSourceDestMatching(t *testing.T) {
 	}
 }
 
+// TestResumePartitionsPlanSpecs checks that partitions with a resume
+// timestamp are planned with a checkpoint that resolves their spans at that
+// timestamp, while other partitions keep the job's checkpoint.
+func TestResumePartitionsPlanSpecs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+
+	nl := func(id int) sql.InstanceLocality {
+		return sql.MakeInstanceLocality(base.SQLInstanceID(id), roachpb.Locality{})
+	}
+	tenantID := roachpb.TenantID{InternalValue: 2}
+	topology := fakeTopology([]sql.InstanceLocality{nl(1), nl(2)}, keys.MakeTenantSpan(tenantID))
+	resumeTS := hlc.Timestamp{WallTime: 42}
+
+	sipSpecs, _, err := constructStreamIngestionPlanSpecs(
+		ctx,
+		topology,
+		[]sql.InstanceLocality{nl(99)},
+		hlc.Timestamp{},
+		hlc.Timestamp{WallTime: 1},
+		jobspb.StreamIngestionCheckpoint{},
+		map[string]hlc.Timestamp{"1": resumeTS},
+		jobspb.InvalidJobID,
+		streampb.StreamID(2),
+		tenantID,
+		tenantID,
+	)
+	require.NoError(t, err)
+
+	var seen int
+	for _, spec := range sipSpecs[99] {
+		for id, partitionSpec := range spec.PartitionSpecs {
+			seen++
+			if id != "1" {
+				require.Empty(t, spec.Checkpoint.ResolvedSpans, "partition %s", id)
+				continue
+			}
+			require.Len(t, spec.Checkpoint.ResolvedSpans, len(partitionSpec.Spans))
+			for i, sp := range partitionSpec.Spans {
+				require.Equal(t, jobspb.ResolvedSpan{Span: sp, Timestamp: resumeTS},
+					spec.Checkpoint.ResolvedSpans[i])
+			}
+		}
+	}
+	require.Equal(t, 2, seen)
+}
+
 type testSplitter struct {
 	mu struct {
 		// fields that may get updated while read are put in the lock.
@@ -392,6 +442,29 @@ func (ts *testSplitter) sortOutput() {
 	})
 }
 
+func TestConfineToTargetNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	instances := []base.SQLInstanceID{1, 2, 3, 4, 5}
+
+	confined, err := confineToTargetNodes(instances, nil /* targetNodeIDs */)
+	require.NoError(t, err)
+	require.Equal(t, instances, confined)
+
+	confined, err = confineToTargetNodes(instances, []roachpb.NodeID{1, 3, 5})
+	require.NoError(t, err)
+	require.Equal(t, []base.SQLInstanceID{1, 3, 5}, confined)
+
+	// Target nodes that aren't available are skipped, as long as one is.
+	confined, err = confineToTargetNodes(instances, []roachpb.NodeID{3, 7})
+	require.NoError(t, err)
+	require.Equal(t, []base.SQLInstanceID{3}, confined)
+
+	_, err = confineToTargetNodes(instances, []roachpb.NodeID{6, 7})
+	require.ErrorContains(t, err, "none of the target nodes [6 7] are available for planning")
+}
+
 func TestCreateInitialSplits(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -10,6 +10,7 @@ package physical
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster"
@@ -19,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
@@ -76,14 +78,89 @@ type streamIngestionFrontier struct {
 
 	lastPartitionUpdate time.Time
 	lastFrontierDump    time.Time
+	// lastReplicatedTimeAdvance is the last time the replicated time was seen
+	// to advance, against which the job's IDLE_TIMEOUT, if any, is measured.
+	lastReplicatedTimeAdvance time.Time
+
+	// lastRefreshCheck is the last time the job progress was checked for a
+	// refresh request, and refreshRequested records whether one was found and
+	// has yet to be served by a progress update.
+	lastRefreshCheck time.Time
+	refreshRequested bool
 
 	lastNodeLagCheck time.Time
 
+	// unpersistedIngestedBytes is the number of bytes the ingestion processors
+	// reported flushing since the job progress was last persisted.
+	unpersistedIngestedBytes int64
+
 	// replicatedTimeAtLastPositiveLagNodeCheck records the replicated time the
 	// last time the lagging node checker detected a lagging node.
 	replicatedTimeAtLastPositiveLagNodeCheck hlc.Timestamp
 }
 
+// initialRetentionCaughtUpLag is how close to the current time the replicated
+// time must first get for a job's initial retention to give way to its steady
+// state retention.
+const initialRetentionCaughtUpLag = time.Minute
+
+// activeReplicationTTLSeconds returns the retention the replication job
+// currently protects history for: its initial retention, if any, until the
+// replicated time has first caught up with the source, and its steady state
+// retention afterwards.
+func activeReplicationTTLSeconds(
+	details *jobspb.StreamIngestionDetails, progress *jobspb.StreamIngestionProgress,
+) int32 {
+	if details.InitialReplicationTTLSeconds != 0 && !progress.InitialRetentionElapsed {
+		return details.InitialReplicationTTLSeconds
+	}
+	return details.ReplicationTTLSeconds
+}
+
+// checkpointInterval returns how often the frontier persists its checkpoint:
+// the job's CHECKPOINT_INTERVAL, if set, and otherwise the job checkpoint
+// frequency cluster setting, where zero disables checkpointing.
+func checkpointInterval(
+	spec *execinfrapb.StreamIngestionFrontierSpec, sv *settings.Values,
+) time.Duration {
+	if spec.CheckpointInterval != 0 {
+		return spec.CheckpointInterval
+	}
+	return crosscluster.JobCheckpointFrequency.Get(sv)
+}
+
+// idleTimeoutElapsed returns true if the job has an IDLE_TIMEOUT and its
+// replicated time has not advanced for that long, in which case the job
+// pauses itself. A job that is cutting over is never considered idle.
+func idleTimeoutElapsed(
+	details *jobspb.StreamIngestionDetails,
+	progress *jobspb.StreamIngestionProgress,
+	lastAdvance, now time.Time,
+) bool {
+	if details.IdleTimeout == 0 || !progress.CutoverTime.IsEmpty() {
+		return false
+	}
+	return now.Sub(lastAdvance) >= details.IdleTimeout
+}
+
+// maybeInitiateAutoCutover signals a cutover to the job's AUTO_CUTOVER time,
+// in the same way COMPLETE REPLICATION does, once the replicated time has
+// reached it. It returns true if it initiated the cutover.
+func maybeInitiateAutoCutover(
+	details *jobspb.StreamIngestionDetails,
+	progress *jobspb.StreamIngestionProgress,
+	replicatedTime hlc.Timestamp,
+) bool {
+	if details.AutoCutoverTime.IsEmpty() || !progress.CutoverTime.IsEmpty() ||
+		replicatedTime.Less(details.AutoCutoverTime) {
+		return false
+	}
+	progress.ReplicationStatus = jobspb.ReplicationPendingCutover
+	progress.CutoverTime = details.AutoCutoverTime
+	progress.RemainingCutoverSpans = roachpb.Spans{details.Span}
+	return true
+}
+
 var _ execinfra.Processor = &streamIngestionFrontier{}
 var _ execinfra.RowSource = &streamIngestionFrontier{}
 
@@ -127,7 +204,8 @@ func newStreamIngestionFrontierProcessor(
 		heartbeatSender: streamclient.NewHeartbeatSender(ctx, streamClient, streamID, func() time.Duration {
 			return crosscluster.StreamReplicationConsumerHeartbeatFrequency.Get(&flowCtx.Cfg.Settings.SV)
 		}),
-		persistedReplicatedTime: spec.ReplicatedTimeAtStart,
+		persistedReplicatedTime:   spec.ReplicatedTimeAtStart,
+		lastReplicatedTimeAdvance: timeutil.Now(),
 	}
 	if err := sf.Init(
 		ctx,
@@ -186,6 +264,10 @@ func (sf *streamIngestionFrontier) Next() (
 			break
 		}
 
+		if err := sf.maybeCheckForRefreshRequest(); err != nil {
+			log.Warningf(sf.Ctx(), "failed to check for replication status refresh request: %v", err)
+		}
+
 		if err := sf.maybeUpdateProgress(); err != nil {
 			log.Errorf(sf.Ctx(), "failed to update progress: %+v", err)
 			sf.MoveToDrainingAndLogError(err)
@@ -280,6 +362,7 @@ func (sf *streamIngestionFrontier) noteResolvedTimestamps(
 	if err != nil {
 		return err
 	}
+	sf.unpersistedIngestedBytes += int64(resolvedSpans.Stats.IngestedBytes)
 	for _, resolved := range resolvedSpans.ResolvedSpans {
 		// Inserting a timestamp less than the one the ingestion flow started at could
 		// potentially regress the job progress. This is not expected and thus we
@@ -302,8 +385,8 @@ func (sf *streamIngestionFrontier) noteResolvedTimestamps(
 // the status of each partition.
 func (sf *streamIngestionFrontier) maybeUpdateProgress() error {
 	ctx := sf.Ctx()
-	updateFreq := crosscluster.JobCheckpointFrequency.Get(&sf.FlowCtx.Cfg.Settings.SV)
-	if updateFreq == 0 || timeutil.Since(sf.lastPartitionUpdate) < updateFreq {
+	updateFreq := checkpointInterval(&sf.spec, &sf.FlowCtx.Cfg.Settings.SV)
+	if !sf.refreshRequested && (updateFreq == 0 || timeutil.Since(sf.lastPartitionUpdate) < updateFreq) {
 		return nil
 	}
 	f := sf.frontier
@@ -318,7 +401,11 @@ func (sf *streamIngestionFrontier) maybeUpdateProgress() error {
 
 	replicatedTime := f.Frontier()
 	sf.lastPartitionUpdate = timeutil.Now()
+	if sf.persistedReplicatedTime.Less(replicatedTime) {
+		sf.lastReplicatedTimeAdvance = sf.lastPartitionUpdate
+	}
 	log.VInfof(ctx, 2, "persisting replicated time of %s", replicatedTime)
+	var tenantID roachpb.TenantID
 	if err := registry.UpdateJobWithTxn(ctx, jobID, nil /* txn */, func(
 		txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
 	) error {
@@ -329,6 +416,8 @@ func (sf *streamIngestionFrontier) maybeUpdateProgress() error {
 		progress := md.Progress
 		streamProgress := progress.Details.(*jobspb.Progress_StreamIngest).StreamIngest
 		streamProgress.Checkpoint.ResolvedSpans = frontierResolvedSpans
+		streamProgress.RefreshRequested = false
+		streamProgress.IngestedBytes += sf.unpersistedIngestedBytes
 
 		// Keep the recorded replicatedTime empty until some advancement has been made
 		if sf.replicatedTimeAtStart.Less(replicatedTime) {
@@ -340,6 +429,27 @@ func (sf *streamIngestionFrontier) maybeUpdateProgress() error {
 			}
 		}
 
+		// Once the replicated time first catches up with the source, the job's
+		// initial retention, if any, gives way to its steady state retention.
+		replicationDetails := md.Payload.GetStreamIngestion()
+		if replicationDetails.InitialReplicationTTLSeconds != 0 && !streamProgress.InitialRetentionElapsed &&
+			!replicatedTime.IsEmpty() && timeutil.Since(replicatedTime.GoTime()) <= initialRetentionCaughtUpLag {
+			log.Infof(ctx, "replicated time %s caught up, relaxing retention from %ds to %ds", replicatedTime,
+				replicationDetails.InitialReplicationTTLSeconds, replicationDetails.ReplicationTTLSeconds)
+			streamProgress.InitialRetentionElapsed = true
+		}
+		if maybeInitiateAutoCutover(replicationDetails, streamProgress, replicatedTime) {
+			log.Infof(ctx, "replicated time %s reached the auto cutover time, cutting over to %s",
+				replicatedTime, replicationDetails.AutoCutoverTime)
+		}
+		if idleTimeoutElapsed(replicationDetails, streamProgress, sf.lastReplicatedTimeAdvance, sf.lastPartitionUpdate) {
+			reason := fmt.Sprintf("replicated time has not advanced for the idle timeout of %s",
+				replicationDetails.IdleTimeout)
+			if err := ju.PauseRequested(ctx, txn, md, reason); err != nil {
+				return err
+			}
+		}
+
 		ju.UpdateProgress(progress)
 
 		// Reset RunStats.NumRuns to 1 since the stream ingestion has returned to
@@ -354,7 +464,7 @@ func (sf *streamIngestionFrontier) maybeUpdateProgress() error {
 		// recorded progress. This makes older revisions of replicated values with a
 		// timestamp less than replicatedTime - ReplicationTTLSeconds eligible for
 		// garbage collection.
-		replicationDetails := md.Payload.GetStreamIngestion()
+		tenantID = replicationDetails.DestinationTenantID
 		if replicationDetails.ProtectedTimestampRecordID == nil {
 			return errors.AssertionFailedf("expected replication job to have a protected timestamp " +
 				"record over the destination tenant's keyspan")
@@ -368,7 +478,8 @@ func (sf *streamIngestionFrontier) maybeUpdateProgress() error {
 		// No need to protect anything below replication start time.
 		replicationStartTime := md.Payload.GetStreamIngestion().ReplicationStartTime
 		newProtectAbove := replicationutils.ResolveHeartbeatTime(
-			replicatedTime, replicationStartTime, streamProgress.CutoverTime, replicationDetails.ReplicationTTLSeconds)
+			replicatedTime, replicationStartTime, streamProgress.CutoverTime,
+			activeReplicationTTLSeconds(replicationDetails, streamProgress))
 
 		sf.heartbeatTime = newProtectAbove
 
@@ -381,8 +492,37 @@ func (sf *streamIngestionFrontier) maybeUpdateProgress() error {
 		return err
 	}
 	sf.metrics.JobProgressUpdates.Inc(1)
+	sf.refreshRequested = false
+	sf.unpersistedIngestedBytes = 0
 	sf.persistedReplicatedTime = f.Frontier()
 	sf.metrics.ReplicatedTimeSeconds.Update(sf.persistedReplicatedTime.GoTime().Unix())
+	if !sf.persistedReplicatedTime.IsEmpty() {
+		sf.metrics.updateReplicatedTimeLag(tenantID, sf.persistedReplicatedTime, timeutil.Now())
+	}
+	return nil
+}
+
+// maybeCheckForRefreshRequest periodically loads the job progress to check
+// whether ALTER VIRTUAL CLUSTER ... REFRESH REPLICATION STATUS has requested
+// that the progress be persisted immediately. It polls at the same interval the
+// ingestion processors poll for the cutover signal.
+func (sf *streamIngestionFrontier) maybeCheckForRefreshRequest() error {
+	if sf.refreshRequested {
+		return nil
+	}
+	pollFreq := cutoverSignalPollInterval.Get(&sf.FlowCtx.Cfg.Settings.SV)
+	if pollFreq == 0 || timeutil.Since(sf.lastRefreshCheck) < pollFreq {
+		return nil
+	}
+	sf.lastRefreshCheck = timeutil.Now()
+	progress, err := replicationutils.LoadIngestionProgress(sf.Ctx(), sf.FlowCtx.Cfg.DB, jobspb.JobID(sf.spec.JobID))
+	if err != nil {
+		return err
+	}
+	if progress != nil && progress.RefreshRequested {
+		log.Infof(sf.Ctx(), "replication status refresh requested")
+		sf.refreshRequested = true
+	}
 	return nil
 }
 
@@ -11,7 +11,13 @@ package physical
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -93,3 +99,92 @@ func TestLaggingNodeErrorHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestActiveReplicationTTLSeconds(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const initial, steady = int32(72 * 60 * 60), int32(24 * 60 * 60)
+	details := &jobspb.StreamIngestionDetails{
+		ReplicationTTLSeconds:        steady,
+		InitialReplicationTTLSeconds: initial,
+	}
+
+	// The initial retention applies until the job has first caught up, after
+	// which the steady state retention does.
+	progress := &jobspb.StreamIngestionProgress{}
+	require.Equal(t, initial, activeReplicationTTLSeconds(details, progress))
+	progress.InitialRetentionElapsed = true
+	require.Equal(t, steady, activeReplicationTTLSeconds(details, progress))
+
+	// Without an initial retention, the steady state retention always applies.
+	details.InitialReplicationTTLSeconds = 0
+	require.Equal(t, steady, activeReplicationTTLSeconds(details, &jobspb.StreamIngestionProgress{}))
+}
+
+func TestMaybeInitiateAutoCutover(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	autoCutover := hlc.Timestamp{WallTime: 100}
+	details := &jobspb.StreamIngestionDetails{
+		Span:            roachpb.Span{Key: roachpb.Key("a"), EndKey: roachpb.Key("z")},
+		AutoCutoverTime: autoCutover,
+	}
+
+	// The cutover is not initiated before the replicated time reaches the auto
+	// cutover time.
+	progress := &jobspb.StreamIngestionProgress{}
+	require.False(t, maybeInitiateAutoCutover(details, progress, hlc.Timestamp{WallTime: 99}))
+	require.True(t, progress.CutoverTime.IsEmpty())
+
+	require.True(t, maybeInitiateAutoCutover(details, progress, hlc.Timestamp{WallTime: 100}))
+	require.Equal(t, autoCutover, progress.CutoverTime)
+	require.Equal(t, jobspb.ReplicationPendingCutover, progress.ReplicationStatus)
+	require.Equal(t, roachpb.Spans{details.Span}, progress.RemainingCutoverSpans)
+
+	// A cutover that is already underway is left alone.
+	progress.RemainingCutoverSpans = nil
+	require.False(t, maybeInitiateAutoCutover(details, progress, hlc.Timestamp{WallTime: 200}))
+	require.Nil(t, progress.RemainingCutoverSpans)
+
+	// Without an auto cutover time, the cutover is never initiated.
+	require.False(t, maybeInitiateAutoCutover(
+		&jobspb.StreamIngestionDetails{}, &jobspb.StreamIngestionProgress{}, hlc.Timestamp{WallTime: 200}))
+}
+
+func TestIdleTimeoutElapsed(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	lastAdvance := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	details := &jobspb.StreamIngestionDetails{IdleTimeout: time.Hour}
+	progress := &jobspb.StreamIngestionProgress{}
+
+	require.False(t, idleTimeoutElapsed(details, progress, lastAdvance, lastAdvance.Add(59*time.Minute)))
+	require.True(t, idleTimeoutElapsed(details, progress, lastAdvance, lastAdvance.Add(time.Hour)))
+
+	// A job that is cutting over does not pause itself.
+	cuttingOver := &jobspb.StreamIngestionProgress{CutoverTime: hlc.Timestamp{WallTime: 1}}
+	require.False(t, idleTimeoutElapsed(details, cuttingOver, lastAdvance, lastAdvance.Add(2*time.Hour)))
+
+	// Without an idle timeout, the job never pauses itself.
+	require.False(t, idleTimeoutElapsed(
+		&jobspb.StreamIngestionDetails{}, progress, lastAdvance, lastAdvance.Add(24*time.Hour)))
+}
+
+func TestCheckpointInterval(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	crosscluster.JobCheckpointFrequency.Override(ctx, &st.SV, 20*time.Second)
+
+	// Without a CHECKPOINT_INTERVAL, the cluster setting applies.
+	spec := &execinfrapb.StreamIngestionFrontierSpec{}
+	require.Equal(t, 20*time.Second, checkpointInterval(spec, &st.SV))
+
+	spec.CheckpointInterval = 15 * time.Second
+	require.Equal(t, 15*time.Second, checkpointInterval(spec, &st.SV))
+}
@@ -61,6 +61,12 @@ func getStreamAddresses(ctx context.Context, ingestionJob *jobs.Job) []string {
 	progress := ingestionJob.Progress()
 	streamAddresses := progress.GetStreamIngest().StreamAddresses
 
+	if progress.GetStreamIngest().RefreshTopology {
+		// The job was resumed WITH REFRESH_TOPOLOGY, so the addresses of its
+		// previous topology may be stale.
+		log.Infof(ctx, "refreshing topology: using stream address found during planning")
+		return []string{details.StreamAddress}
+	}
 	if len(streamAddresses) > 0 {
 		return streamAddresses
 	}
@@ -281,15 +287,32 @@ func ingestWithRetries(
 	return nil
 }
 
-// The ingestion job should never fail, only pause, as progress should never be lost.
+// By default, the ingestion job should never fail, only pause, as progress
+// should never be lost. Jobs created WITH ON_ERROR = 'fail' fail instead, so
+// that automation watching them can react.
 func (s *streamIngestionResumer) handleResumeError(
 	ctx context.Context, execCtx sql.JobExecContext, err error,
 ) error {
-	msg := redact.Sprintf("ingestion job failed (%s) but is being paused", err)
-	updateRunningStatus(ctx, s.job, jobspb.ReplicationError, msg)
-	// The ingestion job is paused but the producer job will keep
-	// running until it times out. Users can still resume ingestion before
-	// the producer job times out.
+	details := s.job.Details().(jobspb.StreamIngestionDetails)
+	if details.OnError == onErrorFail {
+		msg := redact.Sprintf("ingestion job failed (%s)", err)
+		updateRunningStatus(ctx, s.job, jobspb.ReplicationError, msg)
+	} else {
+		msg := redact.Sprintf("ingestion job failed (%s) but is being paused", err)
+		updateRunningStatus(ctx, s.job, jobspb.ReplicationError, msg)
+		// The ingestion job is paused but the producer job will keep
+		// running until it times out. Users can still resume ingestion before
+		// the producer job times out.
+	}
+	return resumeErrorDisposition(details, err)
+}
+
+// resumeErrorDisposition marks the given error such that the job is failed if
+// it was configured WITH ON_ERROR = 'fail', and paused otherwise.
+func resumeErrorDisposition(details jobspb.StreamIngestionDetails, err error) error {
+	if details.OnError == onErrorFail {
+		return jobs.MarkAsPermanentJobError(err)
+	}
 	return jobs.MarkPauseRequestError(err)
 }
 
@@ -498,7 +521,9 @@ func maybeRevertToCutoverTimestamp(
 		batchSize = p.ExecCfg().StreamingTestingKnobs.OverrideRevertRangeBatchSize
 	}
 	// On cutover, replication has stopped so therefore should set replicated time to 0
-	p.ExecCfg().JobRegistry.MetricsStruct().StreamIngest.(*Metrics).ReplicatedTimeSeconds.Update(0)
+	metrics := p.ExecCfg().JobRegistry.MetricsStruct().StreamIngest.(*Metrics)
+	metrics.ReplicatedTimeSeconds.Update(0)
+	metrics.removeReplicatedTimeLag(ingestionJob.Details().(jobspb.StreamIngestionDetails).DestinationTenantID)
 	if err := revertccl.RevertSpansFanout(ctx,
 		p.ExecCfg().DB,
 		p,
@@ -538,6 +563,9 @@ func activateTenant(
 			ClusterID:        details.SourceClusterID,
 			CutoverTimestamp: cutoverTimestamp,
 		}
+		if details.ServiceModeOnComplete != mtinfopb.ServiceModeNone {
+			info.ServiceMode = details.ServiceModeOnComplete
+		}
 
 		return sql.UpdateTenantRecord(ctx, execCfg.Settings, txn, info)
 	})
@@ -560,9 +588,11 @@ func (s *streamIngestionResumer) OnFailOrCancel(
 	// On a job fail or cancel, replication has permanently stopped so set replicated time to 0.
 	// This value can be inadvertently overriden due to the race condition between job cancellation/failure
 	// and the shutdown of ingestion processors.
-	jobExecCtx.ExecCfg().JobRegistry.MetricsStruct().StreamIngest.(*Metrics).ReplicatedTimeSeconds.Update(0)
+	metrics := jobExecCtx.ExecCfg().JobRegistry.MetricsStruct().StreamIngest.(*Metrics)
+	metrics.ReplicatedTimeSeconds.Update(0)
 
 	details := s.job.Details().(jobspb.StreamIngestionDetails)
+	metrics.removeReplicatedTimeLag(details.DestinationTenantID)
 	execCfg := jobExecCtx.ExecCfg()
 	// If we got replicated into another tenant, bail out.
 	if !execCfg.Codec.ForSystemTenant() {
@@ -668,3 +668,25 @@ func waitUntilTenantServerStopped(
 		return nil
 	})
 }
+
+func TestResumeErrorDisposition(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ingestErr := errors.New("ingestion failed")
+	for _, tc := range []struct {
+		onError string
+		fail    bool
+	}{
+		{onError: "", fail: false},
+		{onError: onErrorPause, fail: false},
+		{onError: onErrorFail, fail: true},
+	} {
+		t.Run(fmt.Sprintf("on-error=%q", tc.onError), func(t *testing.T) {
+			err := resumeErrorDisposition(jobspb.StreamIngestionDetails{OnError: tc.onError}, ingestErr)
+			require.ErrorIs(t, err, ingestErr)
+			require.Equal(t, tc.fail, jobs.IsPermanentJobError(err))
+			require.Equal(t, !tc.fail, jobs.IsPauseSelfError(err))
+		})
+	}
+}
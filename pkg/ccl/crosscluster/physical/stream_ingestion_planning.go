@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
@@ -28,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
@@ -41,6 +43,40 @@ const defaultRetentionTTLSeconds = int32(4 * 60 * 60)
 // should only be set from the producer cluster.
 var CannotSetExpirationWindowErr = errors.New("cannot specify EXPIRATION WINDOW option while starting a physical replication stream")
 
+// validateOnlyUnsupportedErr is returned if the VALIDATE_ONLY option is used
+// with any statement other than ALTER VIRTUAL CLUSTER ... START REPLICATION.
+var validateOnlyUnsupportedErr = errors.New("VALIDATE_ONLY is only supported by ALTER VIRTUAL CLUSTER ... START REPLICATION")
+
+// replicationSettings are the cluster settings affecting the behavior of a
+// replication job, which are captured in its details when it is created.
+var replicationSettings = []settings.Setting{
+	crosscluster.StreamReplicationConsumerHeartbeatFrequency,
+	crosscluster.JobCheckpointFrequency,
+	crosscluster.ReplanThreshold,
+	crosscluster.ReplanFrequency,
+	crosscluster.LagCheckFrequency,
+	crosscluster.InterNodeLag,
+	crosscluster.ReplicateSpanConfigsEnabled,
+	minimumFlushInterval,
+	maxKVBufferSize,
+	maxRangeKeyBufferSize,
+	tooSmallRangeKeySize,
+	cutoverSignalPollInterval,
+	quantize,
+	ingestSplitEvent,
+	compress,
+}
+
+// snapshotReplicationSettings returns the current values of the
+// replicationSettings, keyed by setting name.
+func snapshotReplicationSettings(sv *settings.Values) map[string]string {
+	snapshot := make(map[string]string, len(replicationSettings))
+	for _, s := range replicationSettings {
+		snapshot[string(s.Name())] = s.String(sv)
+	}
+	return snapshot
+}
+
 func streamIngestionJobDescription(
 	p sql.PlanHookState, sourceAddr string, streamIngestion *tree.CreateTenantFromReplication,
 ) (string, error) {
@@ -71,12 +107,35 @@ func ingestionTypeCheck(
 		exprutil.TenantSpec{TenantSpec: ingestionStmt.ReplicationSourceTenantName},
 		exprutil.Strings{
 			ingestionStmt.ReplicationSourceAddress,
-			ingestionStmt.Options.Retention},
+			ingestionStmt.Options.Retention,
+			ingestionStmt.Options.RetentionFromZone,
+			ingestionStmt.Options.InitialRetention,
+			ingestionStmt.Options.SeedFromBackup,
+			ingestionStmt.Options.Priority,
+			ingestionStmt.Options.ResumePartitions,
+			ingestionStmt.Options.Owner,
+			ingestionStmt.Options.ServiceModeOnComplete,
+			ingestionStmt.Options.EventSink,
+			ingestionStmt.Options.TargetNodes,
+			ingestionStmt.Options.OnError,
+			ingestionStmt.Options.IdleTimeout,
+			ingestionStmt.Options.SpanConfig,
+			ingestionStmt.Options.EncryptionKey,
+			ingestionStmt.Options.CheckpointInterval,
+			ingestionStmt.Options.IngestMode},
+		exprutil.Ints{
+			ingestionStmt.Options.PauseOnDiskFull,
+			ingestionStmt.Options.MaxPartitionStreams,
+			ingestionStmt.Options.SourceConnPool},
+		exprutil.Bools{ingestionStmt.Options.VerifyChecksums},
 	}
 
 	if err := exprutil.TypeCheck(ctx, "INGESTION", p.SemaCtx(), toTypeCheck...); err != nil {
 		return false, nil, err
 	}
+	if err := typeCheckAutoCutover(ctx, p.SemaCtx(), ingestionStmt.Options, "INGESTION"); err != nil {
+		return false, nil, err
+	}
 
 	return true, nil, nil
 }
@@ -111,8 +170,7 @@ func ingestionPlanHook(
 		return nil, nil, nil, false, err
 	}
 
-	evalCtx := &p.ExtendedEvalContext().Context
-	options, err := evalTenantReplicationOptions(ctx, ingestionStmt.Options, exprEval, evalCtx, p.SemaCtx(), createReplicationOp)
+	options, err := evalTenantReplicationOptions(ctx, p, ingestionStmt.Options, createReplicationOp)
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
@@ -123,6 +181,9 @@ func ingestionPlanHook(
 	if _, ok := options.GetExpirationWindow(); ok {
 		return nil, nil, nil, false, CannotSetExpirationWindowErr
 	}
+	if ingestionStmt.Options.ValidateOnly {
+		return nil, nil, nil, false, validateOnlyUnsupportedErr
+	}
 
 	fn := func(ctx context.Context, _ []sql.PlanNode, _ chan<- tree.Datums) (err error) {
 		defer func() {
@@ -200,6 +261,7 @@ func ingestionPlanHook(
 			sourceTenant,
 			destinationTenantID,
 			retentionTTLSeconds,
+			options,
 			options.resumeTimestamp,
 			hlc.Timestamp{},
 			noRevertFirst,
@@ -218,18 +280,36 @@ func createReplicationJob(
 	sourceTenant string,
 	destinationTenantID roachpb.TenantID,
 	retentionTTLSeconds int32,
+	options *ResolvedTenantReplicationOptions,
 	resumeTimestamp hlc.Timestamp,
 	revertToTimestamp hlc.Timestamp,
 	revertFirst bool,
 	jobID jobspb.JobID,
 	stmt *tree.CreateTenantFromReplication,
 ) error {
+	if partitions, ok := options.GetResumePartitions(); ok {
+		if err := validateResumePartitions(partitions, p.ExecCfg().Clock.Now(), retentionTTLSeconds); err != nil {
+			return err
+		}
+	}
+	initialRetentionTTLSeconds, _ := options.GetInitialRetention()
+	if err := validateInitialRetention(initialRetentionTTLSeconds, retentionTTLSeconds); err != nil {
+		return err
+	}
 
 	// Create a new stream with stream client.
 	client, err := streamclient.NewStreamClient(ctx, streamAddress, p.ExecCfg().InternalDB)
 	if err != nil {
 		return err
 	}
+	if err := timeutil.RunWithTimeout(ctx, "fetching source cluster license",
+		crosscluster.SourceOperationTimeout.Get(&p.ExecCfg().Settings.SV),
+		func(ctx context.Context) error {
+			return validateSourceLicense(ctx, client, p, timeutil.Now(),
+				crosscluster.RequireValidSourceLicense.Get(&p.ExecCfg().Settings.SV))
+		}); err != nil {
+		return errors.CombineErrors(err, client.Close(ctx))
+	}
 
 	// Create the producer job first for the purpose of observability, user is
 	// able to know the producer job id immediately after executing
@@ -261,12 +341,72 @@ func createReplicationJob(
 		Span:                  keys.MakeTenantSpan(destinationTenantID),
 		ReplicationTTLSeconds: retentionTTLSeconds,
 
+		InitialReplicationTTLSeconds: initialRetentionTTLSeconds,
+
 		DestinationTenantID:  destinationTenantID,
 		SourceTenantName:     roachpb.TenantName(sourceTenant),
 		SourceTenantID:       replicationProducerSpec.SourceTenantID,
 		SourceClusterID:      replicationProducerSpec.SourceClusterID,
 		ReplicationStartTime: replicationProducerSpec.ReplicationStartTime,
 	}
+	if seedURI, ok := options.GetSeedFromBackup(); ok {
+		streamIngestionDetails.SeedFromBackup = seedURI
+	}
+	if priority, ok := options.GetPriority(); ok {
+		streamIngestionDetails.Priority = priority
+	}
+	if partitions, ok := options.GetResumePartitions(); ok {
+		streamIngestionDetails.ResumePartitions = partitions
+	}
+	if owner, ok := options.GetOwner(); ok {
+		streamIngestionDetails.Owner = owner
+	}
+	if mode, ok := options.GetServiceModeOnComplete(); ok {
+		streamIngestionDetails.ServiceModeOnComplete = mode
+	}
+	if percent, ok := options.GetPauseOnDiskFull(); ok {
+		streamIngestionDetails.PauseOnDiskFull = percent
+	}
+	if streams, ok := options.GetMaxPartitionStreams(); ok {
+		streamIngestionDetails.MaxPartitionStreams = streams
+	}
+	if interval, ok := options.GetPTSAdvanceInterval(); ok {
+		streamIngestionDetails.PTSAdvanceInterval = interval
+	}
+	if verify, ok := options.GetVerifyChecksums(); ok {
+		streamIngestionDetails.VerifyChecksums = verify
+	}
+	if sink, ok := options.GetEventSink(); ok {
+		streamIngestionDetails.EventSink = sink
+	}
+	if nodeIDs, ok := options.GetTargetNodes(); ok {
+		streamIngestionDetails.TargetNodeIDs = nodeIDs
+	}
+	if onError, ok := options.GetOnError(); ok {
+		streamIngestionDetails.OnError = onError
+	}
+	if ts, ok := options.GetAutoCutover(); ok {
+		streamIngestionDetails.AutoCutoverTime = ts
+	}
+	if timeout, ok := options.GetIdleTimeout(); ok {
+		streamIngestionDetails.IdleTimeout = timeout
+	}
+	if mode, ok := options.GetSpanConfigMode(); ok {
+		streamIngestionDetails.SpanConfigMode = mode
+	}
+	if key, ok := options.GetEncryptionKey(); ok {
+		streamIngestionDetails.EncryptionKey = key
+	}
+	if interval, ok := options.GetCheckpointInterval(); ok {
+		streamIngestionDetails.CheckpointInterval = interval
+	}
+	if size, ok := options.GetSourceConnPool(); ok {
+		streamIngestionDetails.SourceConnPool = size
+	}
+	if mode, ok := options.GetIngestMode(); ok {
+		streamIngestionDetails.IngestMode = mode
+	}
+	streamIngestionDetails.SettingsSnapshot = snapshotReplicationSettings(&p.ExecCfg().Settings.SV)
 
 	jobDescription, err := streamIngestionJobDescription(p, string(streamAddress), stmt)
 	if err != nil {
@@ -285,6 +425,11 @@ func createReplicationJob(
 		Details: streamIngestionDetails,
 	}
 
+	if knobs := p.ExecCfg().StreamingTestingKnobs; knobs != nil && knobs.BeforeIngestionJobCreation != nil {
+		if err := knobs.BeforeIngestionJobCreation(ctx); err != nil {
+			return err
+		}
+	}
 	_, err = p.ExecCfg().JobRegistry.CreateAdoptableJobWithTxn(
 		ctx, jr, jobID, p.InternalSQLTxn(),
 	)
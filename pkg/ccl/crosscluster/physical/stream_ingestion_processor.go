@@ -275,8 +275,8 @@ type streamIngestionProcessor struct {
 	checkpointCh chan *jobspb.ResolvedSpans
 
 	// cutoverCh is used to convey that the ingestion job has been signaled to
-	// cutover.
-	cutoverCh chan struct{}
+	// cutover, and whether it should drain the buffered KVs before doing so.
+	cutoverCh chan bool
 
 	// metrics are monitoring all running ingestion jobs.
 	metrics *Metrics
@@ -338,7 +338,7 @@ func newStreamIngestionDataProcessor(
 			db:    flowCtx.Cfg.DB,
 		},
 		buffer:           &streamIngestionBuffer{},
-		cutoverCh:        make(chan struct{}),
+		cutoverCh:        make(chan bool),
 		stopCh:           make(chan struct{}),
 		flushCh:          make(chan flushableBuffer),
 		checkpointCh:     make(chan *jobspb.ResolvedSpans),
@@ -613,13 +613,13 @@ func (sip *streamIngestionProcessor) checkForCutoverSignal(ctx context.Context)
 		case <-sip.stopCh:
 			return nil
 		case <-tick.C:
-			cutoverReached, err := sip.cutoverProvider.cutoverReached(ctx)
+			cutoverReached, drain, err := sip.cutoverProvider.cutoverReached(ctx)
 			if err != nil {
 				return err
 			}
 			if cutoverReached {
 				select {
-				case sip.cutoverCh <- struct{}{}:
+				case sip.cutoverCh <- drain:
 				case <-sip.stopCh:
 				}
 				return nil
@@ -688,7 +688,7 @@ func (sip *streamIngestionProcessor) consumeEvents(ctx context.Context) error {
 			if err := sip.handleEvent(event); err != nil {
 				return err
 			}
-		case <-sip.cutoverCh:
+		case drain := <-sip.cutoverCh:
 			// TODO(adityamaru): Currently, the cutover time can only be <= resolved
 			// ts written to the job progress and so there is no point flushing
 			// buffered KVs only to be reverted. When we allow users to specify a
@@ -696,6 +696,13 @@ func (sip *streamIngestionProcessor) consumeEvents(ctx context.Context) error {
 			//
 			// On receiving a cutover signal, the processor must shutdown gracefully.
 			log.Infof(sip.Ctx(), "received cutover signal")
+			if drain {
+				// The cutover was requested WITH DRAIN_BEFORE_CUTOVER, so the
+				// buffered KVs are applied before the revert begins. The flush
+				// loop applies them before exiting once we return.
+				log.Infof(sip.Ctx(), "draining buffered KVs before cutover")
+				return sip.flush()
+			}
 			return nil
 		case <-sip.maxFlushRateTimer.C:
 			// This timer is used to periodically flush a
@@ -1252,6 +1259,7 @@ func (sip *streamIngestionProcessor) flush() error {
 	sip.buffer = getBuffer()
 
 	checkpoint := &jobspb.ResolvedSpans{ResolvedSpans: make([]jobspb.ResolvedSpan, 0, sip.frontier.Len())}
+	checkpoint.Stats.IngestedBytes = uint64(bufferToFlush.curKVBatchSize + bufferToFlush.curRangeKVBatchSize)
 	sip.frontier.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
 		if !ts.IsEmpty() {
 			checkpoint.ResolvedSpans = append(checkpoint.ResolvedSpans, jobspb.ResolvedSpan{Span: sp, Timestamp: ts})
@@ -1279,7 +1287,9 @@ type flushableBuffer struct {
 }
 
 // flushBuffer flushes the given streamIngestionBuffer via the SST
-// batchers and returns the underlying streamIngestionBuffer to the pool.
+// batchers and returns the underlying streamIngestionBuffer to the pool. If the
+// job's INGEST_MODE is "row", the point KVs of each SQL row are flushed in
+// their own SST, so that every row is applied on its own.
 func (sip *streamIngestionProcessor) flushBuffer(b flushableBuffer) (*jobspb.ResolvedSpans, error) {
 	ctx, sp := tracing.ChildSpan(sip.Ctx(), "stream-ingestion-flush")
 	defer sp.Finish()
@@ -1290,7 +1300,19 @@ func (sip *streamIngestionProcessor) flushBuffer(b flushableBuffer) (*jobspb.Res
 	//
 	// Ensure that the current batch is sorted.
 	sort.Sort(b.buffer.curKVBatch)
+	rowMode := sip.spec.IngestMode == ingestModeRow
+	var prevRow roachpb.Key
 	for _, keyVal := range b.buffer.curKVBatch {
+		if rowMode {
+			row := rowPrefix(keyVal.Key.Key)
+			if prevRow != nil && !row.Equal(prevRow) {
+				if err := sip.batcher.Flush(ctx); err != nil {
+					return nil, errors.Wrap(err, "flushing sst batcher at row boundary")
+				}
+				sip.batcher.Reset(ctx)
+			}
+			prevRow = row
+		}
 		if err := sip.batcher.AddMVCCKey(ctx, keyVal.Key, keyVal.Value); err != nil {
 			return nil, errors.Wrapf(err, "adding key %+v", keyVal)
 		}
@@ -1323,10 +1345,22 @@ func (sip *streamIngestionProcessor) flushBuffer(b flushableBuffer) (*jobspb.Res
 	return b.checkpoint, nil
 }
 
+// rowPrefix returns the prefix shared by the keys of the SQL row the given key
+// belongs to, or the key itself if it is not part of a SQL row.
+func rowPrefix(key roachpb.Key) roachpb.Key {
+	prefix, err := keys.EnsureSafeSplitKey(key)
+	if err != nil {
+		return key
+	}
+	return prefix
+}
+
 // cutoverProvider allows us to override how we decide when the job has reached
 // the cutover places in tests.
 type cutoverProvider interface {
-	cutoverReached(context.Context) (bool, error)
+	// cutoverReached returns whether the cutover time has been reached and, if
+	// so, whether the processor should drain its buffered KVs before stopping.
+	cutoverReached(context.Context) (reached bool, drain bool, err error)
 }
 
 // custoverFromJobProgress is a cutoverProvider that decides whether the cutover
@@ -1336,23 +1370,23 @@ type cutoverFromJobProgress struct {
 	jobID jobspb.JobID
 }
 
-func (c *cutoverFromJobProgress) cutoverReached(ctx context.Context) (bool, error) {
+func (c *cutoverFromJobProgress) cutoverReached(ctx context.Context) (bool, bool, error) {
 	ingestionProgress, err := replicationutils.LoadIngestionProgress(ctx, c.db, c.jobID)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 	if ingestionProgress == nil {
 		log.Warningf(ctx, "no legacy job progress recorded yet")
-		return false, nil
+		return false, false, nil
 	}
 
 	cutoverTime := ingestionProgress.CutoverTime
 	replicatedTime := ingestionProgress.ReplicatedTime
 	if !cutoverTime.IsEmpty() && cutoverTime.LessEq(replicatedTime) {
-		return true, nil
+		return true, ingestionProgress.DrainBeforeCutover, nil
 	}
 
-	return false, nil
+	return false, false, nil
 }
 
 func init() {
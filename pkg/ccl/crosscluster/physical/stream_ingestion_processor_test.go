@@ -44,6 +44,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/testutils/storageutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/limit"
@@ -508,7 +509,7 @@ func makeTestStreamURI(
 
 type noCutover struct{}
 
-func (n noCutover) cutoverReached(context.Context) (bool, error) { return false, nil }
+func (n noCutover) cutoverReached(context.Context) (bool, bool, error) { return false, false, nil }
 
 // TestRandomClientGeneration tests the ingestion processor against a random
 // stream workload.
@@ -806,6 +807,26 @@ func validateFnWithValidator(
 	}
 }
 
+func TestRowPrefix(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	codec := keys.MakeSQLCodec(roachpb.MustMakeTenantID(10))
+	rowKey := func(pk uint64) roachpb.Key {
+		return encoding.EncodeUvarintAscending(codec.IndexPrefix(104, 1), pk)
+	}
+
+	// The column families of a row share its prefix, which differs from that of
+	// the next row.
+	row1 := rowKey(1)
+	require.Equal(t, row1, rowPrefix(keys.MakeFamilyKey(row1, 0)))
+	require.Equal(t, row1, rowPrefix(keys.MakeFamilyKey(row1, 2)))
+	require.NotEqual(t, row1, rowPrefix(keys.MakeFamilyKey(rowKey(2), 0)))
+
+	// Keys that are not part of a SQL row are their own prefix.
+	require.Equal(t, keys.LocalMax, rowPrefix(keys.LocalMax))
+}
+
 // makeCheckpointEventCounter runs f after seeing `threshold` number of
 // checkpoint events.
 func makeCheckpointEventCounter(
@@ -59,6 +59,72 @@ var JobCheckpointFrequency = settings.RegisterDurationSetting(
 	settings.WithName("physical_replication.consumer.job_checkpoint_frequency"),
 )
 
+// SourceOperationTimeout bounds each interaction with the source cluster made
+// while starting replication into an existing virtual cluster.
+var SourceOperationTimeout = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"physical_replication.consumer.source_operation_timeout",
+	"the maximum duration of each interaction with the source cluster made while "+
+		"starting replication into an existing virtual cluster",
+	time.Minute,
+	settings.PositiveDuration,
+)
+
+// CutoverEventsTable names the table from which COMPLETE REPLICATION TO EVENT
+// resolves the cutover time of a named event.
+var CutoverEventsTable = settings.RegisterStringSetting(
+	settings.SystemOnly,
+	"physical_replication.consumer.cutover_events_table",
+	"name of the table from which COMPLETE REPLICATION TO EVENT resolves cutover times; "+
+		"the table must have a STRING name column and a DECIMAL or TIMESTAMPTZ event_time column",
+	"",
+)
+
+// CutoverDataLossThreshold bounds how far behind the replicated time a cutover
+// time may be before the cutover has to explicitly acknowledge the loss of the
+// data replicated in between.
+var CutoverDataLossThreshold = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"physical_replication.consumer.cutover_data_loss_threshold",
+	"the maximum duration by which a cutover time may be behind the replicated time "+
+		"without specifying WITH ALLOW_DATA_LOSS; if 0, disabled",
+	time.Hour,
+	settings.NonNegativeDuration,
+)
+
+// RequireValidSourceLicense controls whether starting replication fails, rather
+// than only warns, when the source cluster's license has expired or is about to.
+var RequireValidSourceLicense = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"physical_replication.consumer.require_valid_source_license",
+	"if true, starting replication fails rather than warns when the source "+
+		"cluster's license has expired or is about to expire",
+	false,
+)
+
+// MaxActiveIngestionJobs is the number of replication consumer jobs the
+// cluster is provisioned to run at once, against which SHOW REPLICATION
+// CAPACITY reports the remaining headroom.
+var MaxActiveIngestionJobs = settings.RegisterIntSetting(
+	settings.SystemOnly,
+	"physical_replication.consumer.max_active_jobs",
+	"the number of replication consumer jobs the cluster is provisioned to run "+
+		"at once, as reported by SHOW REPLICATION CAPACITY; if 0, unlimited",
+	0,
+	settings.NonNegativeInt,
+)
+
+// MinReplicationRetention is the shortest retention a replication job may be
+// configured with, so that operators can ensure cutover to a point in the
+// retained history always remains possible.
+var MinReplicationRetention = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"physical_replication.consumer.min_retention",
+	"the minimum RETENTION a replication job may be configured with; if 0, no minimum is enforced",
+	0,
+	settings.NonNegativeDuration,
+)
+
 var ReplanThreshold = settings.RegisterFloatSetting(
 	settings.SystemOnly,
 	"stream_replication.replan_flow_threshold",
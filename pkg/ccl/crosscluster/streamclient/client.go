@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster"
 	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn"
@@ -113,6 +114,23 @@ type Client interface {
 		ctx context.Context, tenant roachpb.TenantName,
 	) (id string, replicatedFrom string, activated hlc.Timestamp, _ error)
 
+	// EarliestProtectableTimestamp returns the earliest timestamp as of which
+	// the source cluster still has tenant data that it could protect, along
+	// with the source's current time. Data below the current time less the GC
+	// TTL of the tenant ranges may already have been garbage collected. An
+	// empty floor is returned if the client cannot determine it, e.g. if it
+	// lacks the privileges to read the source's zone configurations.
+	EarliestProtectableTimestamp(ctx context.Context) (floor hlc.Timestamp, now hlc.Timestamp, _ error)
+
+	// ClusterVersion returns the active cluster version of the source cluster.
+	// An empty version is returned if the client cannot determine it.
+	ClusterVersion(ctx context.Context) (roachpb.Version, error)
+
+	// LicenseExpiration returns the time at which the source cluster's license
+	// expires. A zero time is returned if the source has no license, if its
+	// license does not expire, or if the client cannot determine it.
+	LicenseExpiration(ctx context.Context) (time.Time, error)
+
 	PlanLogicalReplication(ctx context.Context, req streampb.LogicalReplicationPlanRequest) (LogicalReplicationPlan, error)
 	CreateForTables(ctx context.Context, req *streampb.ReplicationProducerRequest) (*streampb.ReplicationProducerSpec, error)
 }
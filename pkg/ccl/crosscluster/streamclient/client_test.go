@@ -140,6 +140,23 @@ func (sc testStreamClient) PriorReplicationDetails(
 	return "", "", hlc.Timestamp{}, nil
 }
 
+// EarliestProtectableTimestamp implements the streamclient.Client interface.
+func (sc testStreamClient) EarliestProtectableTimestamp(
+	_ context.Context,
+) (hlc.Timestamp, hlc.Timestamp, error) {
+	return hlc.Timestamp{}, hlc.Timestamp{}, nil
+}
+
+// ClusterVersion implements the streamclient.Client interface.
+func (sc testStreamClient) ClusterVersion(_ context.Context) (roachpb.Version, error) {
+	return roachpb.Version{}, nil
+}
+
+// LicenseExpiration implements the streamclient.Client interface.
+func (sc testStreamClient) LicenseExpiration(_ context.Context) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 type testStreamSubscription struct {
 	eventCh chan crosscluster.Event
 }
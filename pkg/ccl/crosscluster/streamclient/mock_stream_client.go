@@ -10,6 +10,7 @@ package streamclient
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster"
 	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
@@ -134,6 +135,23 @@ func (m *MockStreamClient) PriorReplicationDetails(
 	return "", "", hlc.Timestamp{}, nil
 }
 
+// EarliestProtectableTimestamp implements the streamclient.Client interface.
+func (m *MockStreamClient) EarliestProtectableTimestamp(
+	_ context.Context,
+) (hlc.Timestamp, hlc.Timestamp, error) {
+	return hlc.Timestamp{}, hlc.Timestamp{}, nil
+}
+
+// ClusterVersion implements the streamclient.Client interface.
+func (m *MockStreamClient) ClusterVersion(_ context.Context) (roachpb.Version, error) {
+	return roachpb.Version{}, nil
+}
+
+// LicenseExpiration implements the streamclient.Client interface.
+func (m *MockStreamClient) LicenseExpiration(_ context.Context) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 func (p *MockStreamClient) PlanLogicalReplication(
 	_ context.Context, req streampb.LogicalReplicationPlanRequest,
 ) (LogicalReplicationPlan, error) {
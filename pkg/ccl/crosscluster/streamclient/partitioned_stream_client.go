@@ -14,21 +14,28 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"time"
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/cockroachdb/cockroach/pkg/ccl/crosscluster"
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl/licenseccl"
+	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/repstream/streampb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/span"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
+	"gopkg.in/yaml.v2"
 )
 
 type partitionedStreamClient struct {
@@ -409,6 +416,79 @@ func (p *partitionedStreamClient) PriorReplicationDetails(
 	return id, "", hlc.Timestamp{}, nil
 }
 
+// EarliestProtectableTimestamp implements the streamclient.Client interface.
+func (p *partitionedStreamClient) EarliestProtectableTimestamp(
+	ctx context.Context,
+) (hlc.Timestamp, hlc.Timestamp, error) {
+	ctx, sp := tracing.ChildSpan(ctx, "streamclient.Client.EarliestProtectableTimestamp")
+	defer sp.Finish()
+
+	var nowStr, zoneYAML string
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	row := p.mu.srcConn.QueryRow(ctx,
+		`SELECT cluster_logical_timestamp()::string,
+       (SELECT full_config_yaml FROM crdb_internal.zones WHERE target = 'RANGE tenants')`)
+	if err := row.Scan(&nowStr, &zoneYAML); err != nil {
+		// Reading the zone configurations requires privileges the replication
+		// user on the source may not have, in which case the floor is unknown.
+		if pgErr := (*pgconn.PgError)(nil); errors.As(err, &pgErr) {
+			if pgcode.MakeCode(pgErr.Code) == pgcode.InsufficientPrivilege {
+				log.Warningf(ctx, "cannot determine the source's earliest protectable timestamp: %v", err)
+				return hlc.Timestamp{}, hlc.Timestamp{}, nil
+			}
+		}
+		return hlc.Timestamp{}, hlc.Timestamp{}, errors.Wrap(err, "error querying source protected timestamp state")
+	}
+
+	now, err := hlc.ParseHLC(nowStr)
+	if err != nil {
+		return hlc.Timestamp{}, hlc.Timestamp{}, err
+	}
+	var zone zonepb.ZoneConfig
+	if err := yaml.UnmarshalStrict([]byte(zoneYAML), &zone); err != nil {
+		return hlc.Timestamp{}, hlc.Timestamp{}, errors.Wrap(err, "error decoding source tenant zone config")
+	}
+	return now.AddDuration(-time.Duration(zone.GC.TTLSeconds) * time.Second), now, nil
+}
+
+// ClusterVersion implements the streamclient.Client interface.
+func (p *partitionedStreamClient) ClusterVersion(ctx context.Context) (roachpb.Version, error) {
+	ctx, sp := tracing.ChildSpan(ctx, "streamclient.Client.ClusterVersion")
+	defer sp.Finish()
+
+	var versionStr string
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	row := p.mu.srcConn.QueryRow(ctx, `SHOW CLUSTER SETTING version`)
+	if err := row.Scan(&versionStr); err != nil {
+		return roachpb.Version{}, errors.Wrap(err, "error querying source cluster version")
+	}
+	return roachpb.ParseVersion(versionStr)
+}
+
+// LicenseExpiration implements the streamclient.Client interface.
+func (p *partitionedStreamClient) LicenseExpiration(ctx context.Context) (time.Time, error) {
+	ctx, sp := tracing.ChildSpan(ctx, "streamclient.Client.LicenseExpiration")
+	defer sp.Finish()
+
+	var encoded string
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	row := p.mu.srcConn.QueryRow(ctx, `SHOW CLUSTER SETTING enterprise.license`)
+	if err := row.Scan(&encoded); err != nil {
+		return time.Time{}, errors.Wrap(err, "error querying source cluster license")
+	}
+	license, err := licenseccl.Decode(encoded)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "error decoding source cluster license")
+	}
+	if license == nil || license.ValidUntilUnixSec == 0 {
+		return time.Time{}, nil
+	}
+	return timeutil.Unix(license.ValidUntilUnixSec, 0), nil
+}
+
 type partitionedStreamSubscription struct {
 	err           error
 	srcConnConfig *pgx.ConnConfig
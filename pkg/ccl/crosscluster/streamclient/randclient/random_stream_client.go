@@ -631,6 +631,23 @@ func (p *RandomStreamClient) PriorReplicationDetails(
 
 }
 
+// EarliestProtectableTimestamp implements the streamclient.Client interface.
+func (p *RandomStreamClient) EarliestProtectableTimestamp(
+	_ context.Context,
+) (hlc.Timestamp, hlc.Timestamp, error) {
+	return hlc.Timestamp{}, hlc.Timestamp{}, nil
+}
+
+// ClusterVersion implements the streamclient.Client interface.
+func (p *RandomStreamClient) ClusterVersion(_ context.Context) (roachpb.Version, error) {
+	return roachpb.Version{}, nil
+}
+
+// LicenseExpiration implements the streamclient.Client interface.
+func (p *RandomStreamClient) LicenseExpiration(_ context.Context) (time.Time, error) {
+	return time.Time{}, nil
+}
+
 type randomStreamSubscription struct {
 	receiveFn func(ctx context.Context) error
 	eventCh   chan crosscluster.Event
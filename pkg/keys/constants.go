@@ -305,6 +305,9 @@ var (
 	// set during cluster initialization, by which a license must be installed to avoid
 	// throttling. The value is stored as the number of seconds since the Unix epoch.
 	GracePeriodInitTimestamp = roachpb.Key(makeKey(SystemPrefix, roachpb.RKey("lic-gpi-ts")))
+	// MigrationLockPrefix is the key prefix under which the cluster-wide locks
+	// held by upgrade coordinators are stored.
+	MigrationLockPrefix = roachpb.Key(makeKey(SystemPrefix, roachpb.RKey("migration-lock/")))
 	//
 	// NodeIDGenerator is the global node ID generator sequence.
 	NodeIDGenerator = roachpb.Key(makeKey(SystemPrefix, roachpb.RKey("node-idgen")))
@@ -249,6 +249,7 @@ var _ = [...]interface{}{
 	NodeLivenessPrefix,       // "\x00liveness-"
 	BootstrapVersionKey,      // "bootstrap-version"
 	GracePeriodInitTimestamp, // "lic-gpi-ts"
+	MigrationLockPrefix,      // "migration-lock/"
 	NodeIDGenerator,          // "node-idgen"
 	RangeIDGenerator,         // "range-idgen"
 	StatusPrefix,             // "status-"
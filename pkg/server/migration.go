@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvstorage"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -153,6 +154,35 @@ func bumpClusterVersion(
 	return nil
 }
 
+// GetClusterVersion implements the MigrationServer interface. It returns the
+// active cluster version as currently observed by this node.
+func (m *migrationServer) GetClusterVersion(
+	ctx context.Context, _ *serverpb.GetClusterVersionRequest,
+) (*serverpb.GetClusterVersionResponse, error) {
+	cv := m.server.ClusterSettings().Version.ActiveVersion(ctx)
+	return &serverpb.GetClusterVersionResponse{ClusterVersion: &cv}, nil
+}
+
+// GetSettingValue implements the MigrationServer interface. It returns the
+// value of the given setting as currently observed by this node.
+func (m *migrationServer) GetSettingValue(
+	ctx context.Context, req *serverpb.GetSettingValueRequest,
+) (*serverpb.GetSettingValueResponse, error) {
+	return getSettingValue(m.server.ClusterSettings(), req.Key, settings.ForSystemTenant)
+}
+
+// getSettingValue returns the encoded value of the setting with the given key
+// in st.
+func getSettingValue(
+	st *cluster.Settings, key string, forSystemTenant bool,
+) (*serverpb.GetSettingValueResponse, error) {
+	s, ok := settings.LookupForLocalAccessByKey(settings.InternalKey(key), forSystemTenant)
+	if !ok {
+		return nil, errors.Newf("unknown setting %q", key)
+	}
+	return &serverpb.GetSettingValueResponse{Value: s.Encoded(&st.SV)}, nil
+}
+
 // SyncAllEngines implements the MigrationServer interface.
 func (m *migrationServer) SyncAllEngines(
 	ctx context.Context, _ *serverpb.SyncAllEnginesRequest,
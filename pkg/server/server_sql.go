@@ -1268,6 +1268,8 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 				Dialer:           cfg.kvNodeDialer,
 				RangeDescScanner: rangedesc.NewScanner(cfg.db),
 				DB:               cfg.db,
+				NodeID:           cfg.nodeIDContainer,
+				Executor:         cfg.circularInternalExecutor,
 			})
 		} else {
 			c = upgradecluster.NewTenantCluster(
@@ -169,6 +169,24 @@ func bumpTenantClusterVersion(
 	return nil
 }
 
+// GetClusterVersion implements the MigrationServer interface. It returns the
+// active cluster version as currently observed by this tenant server.
+func (m *TenantMigrationServer) GetClusterVersion(
+	ctx context.Context, _ *serverpb.GetClusterVersionRequest,
+) (*serverpb.GetClusterVersionResponse, error) {
+	cv := m.sqlServer.settingsWatcher.GetTenantClusterVersion().ActiveVersion(ctx)
+	return &serverpb.GetClusterVersionResponse{ClusterVersion: &cv}, nil
+}
+
+// GetSettingValue implements the MigrationServer interface. It returns the
+// value of the given setting as currently observed by this tenant server.
+func (m *TenantMigrationServer) GetSettingValue(
+	ctx context.Context, req *serverpb.GetSettingValueRequest,
+) (*serverpb.GetSettingValueResponse, error) {
+	execCfg := m.sqlServer.execCfg
+	return getSettingValue(execCfg.Settings, req.Key, execCfg.Codec.ForSystemTenant())
+}
+
 // SyncAllEngines implements the MigrationServer interface.
 func (m *TenantMigrationServer) SyncAllEngines(
 	ctx context.Context, _ *serverpb.SyncAllEnginesRequest,
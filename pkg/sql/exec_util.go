@@ -1830,6 +1830,10 @@ type StreamingTestingKnobs struct {
 	// before a stream ingestion happens.
 	BeforeIngestionStart func(ctx context.Context) error
 
+	// BeforeIngestionJobCreation, if set, is called before the stream ingestion
+	// job record is created, and can inject an error into its creation.
+	BeforeIngestionJobCreation func(ctx context.Context) error
+
 	// AfterReplicationFlowPlan allows the caller to inspect the ingestion and
 	// frontier specs generated for the replication job.
 	AfterReplicationFlowPlan func(map[base.SQLInstanceID][]execinfrapb.StreamIngestionDataSpec,
@@ -425,6 +425,7 @@ func init() {
 		&tree.ScheduledBackup{},
 		&tree.CreateTenantFromReplication{},
 		&tree.CreateLogicalReplicationStream{},
+		&tree.ShowTenantReplication{},
 	} {
 		typ := optbuilder.OpaqueReadOnly
 		if tree.CanModifySchema(stmt) {
@@ -457,6 +457,20 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW LOGICAL REPLICATION JOBS ??`, `SHOW LOGICAL REPLICATION JOBS`},
 		{`SHOW LOGICAL REPLICATION JOBS ?? WITH DETAILS`, `SHOW LOGICAL REPLICATION JOBS`},
 
+		{`SHOW REPLICATION STATS ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION STATS FOR ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION CLOCK ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION CLOCK FOR ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION SETTINGS ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION SETTINGS FOR ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION PTS ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION PTS FOR ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION THROUGHPUT ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION THROUGHPUT FOR ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION CAPACITY ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION TOPOLOGY ??`, `SHOW REPLICATION`},
+		{`SHOW REPLICATION TOPOLOGY FOR ??`, `SHOW REPLICATION`},
+
 		{`SHOW PARTITIONS FROM ??`, `SHOW PARTITIONS`},
 
 		{`SHOW REGIONS ??`, `SHOW REGIONS`},
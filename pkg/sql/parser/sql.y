@@ -869,6 +869,9 @@ func (u *sqlSymUnion) tenantReplicationOptions() *tree.TenantReplicationOptions
 func (u *sqlSymUnion) showRangesOpts() *tree.ShowRangesOptions {
     return u.val.(*tree.ShowRangesOptions)
 }
+func (u *sqlSymUnion) replicationCutoverOptions() *tree.ReplicationCutoverOptions {
+    return u.val.(*tree.ReplicationCutoverOptions)
+}
 func (u *sqlSymUnion) tenantSpec() *tree.TenantSpec {
     return u.val.(*tree.TenantSpec)
 }
@@ -933,16 +936,16 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
-%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
-%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
+%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN ADOPT AFTER AGGREGATE
+%token <str> ALL ALLOW_DATA_LOSS ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
+%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AUTO_CUTOVER AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY
 
-%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
-%token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
+%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CAPACITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CHARACTER CHARACTERISTICS CHECK CHECKPOINT_INTERVAL CHECK_FILES CLEAR CLOCK CLOCK_SKEW_TOLERANCE CLOSE
 %token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
@@ -953,9 +956,9 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DRAIN_BEFORE_CUTOVER DROP
 
-%token <str> EACH ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_KEY ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EVENT EVENT_SINK EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -973,12 +976,12 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 
 %token <str> HAVING HASH HEADER HIGH HISTOGRAM HOLD HOUR
 
-%token <str> IDENTITY
+%token <str> IDENTITY IDLE_TIMEOUT
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS IGNORE_CDC_IGNORED_TTL_DELETES ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
-%token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
+%token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT INGEST_MODE INITIAL_RETENTION
 %token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
@@ -991,7 +994,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
 %token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGICAL LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
+%token <str> MATCH MATERIALIZED MAX_PARTITION_STREAMS MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
@@ -1003,33 +1006,33 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
-%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY OPT OPTION OPTIONS OR
+%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY ON_ERROR OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PHYSICAL PLACEMENT PLACING
+%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PAUSE_ON_DISK_FULL PER PHYSICAL PLACEMENT PLACING
 %token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
-%token <str> PROCEDURAL PROCEDURE PROCEDURES PUBLIC PUBLICATION
+%token <str> PROCEDURAL PROCEDURE PROCEDURES PTS PTS_ADVANCE_INTERVAL PUBLIC PUBLICATION
 
 %token <str> QUERIES QUERY QUOTE
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
+%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCE REFERENCES REFERENCING REFRESH REFRESH_TOPOLOGY
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATION
-%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
+%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RESUME_PARTITIONS RETENTION RETURNING RETURN RETURNS RETRY REVERT REVISION_HISTORY
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
-%token <str> SEARCH SECOND SECONDARY SECURITY SELECT SEQUENCE SEQUENCES
-%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
+%token <str> SEARCH SECOND SECONDARY SECURITY SEED_FROM_BACKUP SELECT SEQUENCE SEQUENCES
+%token <str> SERIALIZABLE SERVER SERVICE SERVICE_MODE_ON_COMPLETE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
 %token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
-%token <str> SNAPSHOT SOME SPLIT SQL SQLLOGIN
-%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
+%token <str> SNAPSHOT SOME SOURCE SOURCE_CONN_POOL SPAN_CONFIG SPLIT SQL SQLLOGIN
+%token <str> STABLE START STATE STATEMENT STATISTICS STATS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
-%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
-%token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
+%token <str> TABLE TABLES TABLESPACE TARGET TARGET_NODES TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TEST TESTING_RELOCATE TEXT THEN THROUGHPUT
+%token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TOPOLOGY TRAILING TRACE
 %token <str> TRANSACTION TRANSACTIONS TRANSFER TRANSFORM TREAT TRIGGER TRIM TRUE
 %token <str> TRUNCATE TRUSTED TYPE TYPES
 %token <str> TRACING
@@ -1037,7 +1040,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLISTEN UNLOGGED UNSAFE_RESTORE_INCOMPATIBLE_VERSION UNSPLIT
 %token <str> UPDATE UPDATES_CLUSTER_MONITORING_METRICS UPSERT UNSET UNTIL USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VECTOR VERIFY_BACKUP_TABLE_DATA VIEW VARIABLES VARYING VIEWACTIVITY VIEWACTIVITYREDACTED VIEWDEBUG
+%token <str> VALID VALIDATE VALIDATE_ONLY VALUE VALUES VARBIT VARCHAR VARIADIC VECTOR VERIFY_BACKUP_TABLE_DATA VERIFY_CHECKSUMS VIEW VARIABLES VARYING VIEWACTIVITY VIEWACTIVITYREDACTED VIEWDEBUG
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
@@ -1357,6 +1360,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <tree.Statement> show_full_scans_stmt
 %type <tree.Statement> show_completions_stmt
 %type <tree.Statement> show_logical_replication_jobs_stmt opt_show_logical_replication_jobs_options show_logical_replication_jobs_options
+%type <tree.Statement> show_replication_stmt
 
 %type <str> statements_or_queries
 
@@ -1389,6 +1393,7 @@ func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
 %type <*tree.TenantReplicationOptions> opt_with_replication_options replication_options replication_options_list
+%type <*tree.ReplicationCutoverOptions> opt_with_cutover_options cutover_options_list cutover_options
 %type <tree.ShowBackupDetails> show_backup_details
 %type <*tree.ShowJobOptions> show_job_options show_job_options_list
 %type <*tree.ShowBackupOptions> opt_with_show_backup_options show_backup_options show_backup_options_list show_backup_connection_options opt_with_show_backup_connection_options_list show_backup_connection_options_list
@@ -4821,11 +4826,121 @@ replication_options:
   {
     $$.val = &tree.TenantReplicationOptions{Retention: $3.expr()}
   }
+|
+  RETENTION '=' FROM ZONE d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{RetentionFromZone: $5.expr()}
+  }
+|
+  INITIAL_RETENTION '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{InitialRetention: $3.expr()}
+  }
 |
   EXPIRATION WINDOW '=' d_expr
   {
       $$.val = &tree.TenantReplicationOptions{ExpirationWindow: $4.expr()}
   }
+|
+  SEED_FROM_BACKUP '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{SeedFromBackup: $3.expr()}
+  }
+|
+  PRIORITY '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{Priority: $3.expr()}
+  }
+|
+  RESUME_PARTITIONS '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{ResumePartitions: $3.expr()}
+  }
+|
+  OWNER '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{Owner: $3.expr()}
+  }
+|
+  SERVICE_MODE_ON_COMPLETE '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{ServiceModeOnComplete: $3.expr()}
+  }
+|
+  PAUSE_ON_DISK_FULL '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{PauseOnDiskFull: $3.expr()}
+  }
+|
+  MAX_PARTITION_STREAMS '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{MaxPartitionStreams: $3.expr()}
+  }
+|
+  PTS_ADVANCE_INTERVAL '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{PTSAdvanceInterval: $3.expr()}
+  }
+|
+  VERIFY_CHECKSUMS '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{VerifyChecksums: $3.expr()}
+  }
+|
+  EVENT_SINK '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{EventSink: $3.expr()}
+  }
+|
+  TARGET_NODES '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{TargetNodes: $3.expr()}
+  }
+|
+  ON_ERROR '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{OnError: $3.expr()}
+  }
+|
+  AUTO_CUTOVER '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{AutoCutover: $3.expr()}
+  }
+|
+  IDLE_TIMEOUT '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{IdleTimeout: $3.expr()}
+  }
+|
+  SPAN_CONFIG '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{SpanConfig: $3.expr()}
+  }
+|
+  ENCRYPTION_KEY '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{EncryptionKey: $3.expr()}
+  }
+|
+  CHECKPOINT_INTERVAL '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{CheckpointInterval: $3.expr()}
+  }
+|
+  SOURCE_CONN_POOL '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{SourceConnPool: $3.expr()}
+  }
+|
+  INGEST_MODE '=' d_expr
+  {
+    $$.val = &tree.TenantReplicationOptions{IngestMode: $3.expr()}
+  }
+|
+  VALIDATE_ONLY
+  {
+    $$.val = &tree.TenantReplicationOptions{ValidateOnly: true}
+  }
 
 // %Help: CREATE SCHEDULE
 // %Category: Group
@@ -6680,6 +6795,90 @@ show_logical_replication_jobs_options:
     $$.val = tree.ShowLogicalReplicationJobsOptions{WithDetails: true}
   }
 
+// %Help: SHOW REPLICATION - display details about a virtual cluster's replication stream
+// %Category: Experimental
+// %Text:
+// SHOW REPLICATION STATS FOR VIRTUAL CLUSTER <virtual_cluster_spec>
+// SHOW REPLICATION CLOCK FOR VIRTUAL CLUSTER <virtual_cluster_spec>
+// SHOW REPLICATION SETTINGS FOR VIRTUAL CLUSTER <virtual_cluster_spec>
+// SHOW REPLICATION OPTIONS FOR VIRTUAL CLUSTER <virtual_cluster_spec>
+// SHOW REPLICATION PTS FOR VIRTUAL CLUSTER <virtual_cluster_spec>
+// SHOW REPLICATION THROUGHPUT FOR VIRTUAL CLUSTER <virtual_cluster_spec>
+// SHOW REPLICATION CAPACITY
+// SHOW REPLICATION TOPOLOGY FOR VIRTUAL CLUSTER <virtual_cluster_spec>
+show_replication_stmt:
+  SHOW REPLICATION STATS FOR virtual_cluster virtual_cluster_spec
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTenantReplication{
+      Kind: tree.ShowReplicationStats,
+      TenantSpec: $6.tenantSpec(),
+    }
+  }
+| SHOW REPLICATION CLOCK FOR virtual_cluster virtual_cluster_spec
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTenantReplication{
+      Kind: tree.ShowReplicationClock,
+      TenantSpec: $6.tenantSpec(),
+    }
+  }
+| SHOW REPLICATION SETTINGS FOR virtual_cluster virtual_cluster_spec
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTenantReplication{
+      Kind: tree.ShowReplicationSettings,
+      TenantSpec: $6.tenantSpec(),
+    }
+  }
+| SHOW REPLICATION OPTIONS FOR virtual_cluster virtual_cluster_spec
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTenantReplication{
+      Kind: tree.ShowReplicationOptions,
+      TenantSpec: $6.tenantSpec(),
+    }
+  }
+| SHOW REPLICATION PTS FOR virtual_cluster virtual_cluster_spec
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTenantReplication{
+      Kind: tree.ShowReplicationPTS,
+      TenantSpec: $6.tenantSpec(),
+    }
+  }
+| SHOW REPLICATION THROUGHPUT FOR virtual_cluster virtual_cluster_spec
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTenantReplication{
+      Kind: tree.ShowReplicationThroughput,
+      TenantSpec: $6.tenantSpec(),
+    }
+  }
+| SHOW REPLICATION CAPACITY
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTenantReplication{
+      Kind: tree.ShowReplicationCapacity,
+    }
+  }
+| SHOW REPLICATION TOPOLOGY FOR virtual_cluster virtual_cluster_spec
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowTenantReplication{
+      Kind: tree.ShowReplicationTopology,
+      TenantSpec: $6.tenantSpec(),
+    }
+  }
+| SHOW REPLICATION STATS error // SHOW HELP: SHOW REPLICATION
+| SHOW REPLICATION CLOCK error // SHOW HELP: SHOW REPLICATION
+| SHOW REPLICATION SETTINGS error // SHOW HELP: SHOW REPLICATION
+| SHOW REPLICATION OPTIONS error // SHOW HELP: SHOW REPLICATION
+| SHOW REPLICATION PTS error // SHOW HELP: SHOW REPLICATION
+| SHOW REPLICATION THROUGHPUT error // SHOW HELP: SHOW REPLICATION
+| SHOW REPLICATION CAPACITY error // SHOW HELP: SHOW REPLICATION
+| SHOW REPLICATION TOPOLOGY error // SHOW HELP: SHOW REPLICATION
+
 // %Help: PREPARE - prepare a statement for later execution
 // %Category: Misc
 // %Text: PREPARE <name> [ ( <types...> ) ] AS <query>
@@ -7361,10 +7560,20 @@ alter_virtual_cluster_service_stmt:
 // %Category: Experimental
 // %Text:
 // ALTER VIRTUAL CLUSTER <virtual_cluster_spec> PAUSE REPLICATION
-// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> RESUME REPLICATION
-// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> COMPLETE REPLICATION TO LATEST
-// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> COMPLETE REPLICATION TO SYSTEM TIME 'time'
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> RESUME REPLICATION [WITH REFRESH_TOPOLOGY]
+// ALTER VIRTUAL CLUSTER ALL { PAUSE | RESUME } REPLICATION
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> COMPLETE REPLICATION TO LATEST [WITH opt[=value],...]
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> COMPLETE REPLICATION TO SYSTEM TIME 'time' [WITH opt[=value],...]
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> COMPLETE REPLICATION TO EVENT 'name' [WITH opt[=value],...]
 // ALTER VIRTUAL CLUSTER <virtual_cluster_spec> SET REPLICATION opt=value,...
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> REFRESH REPLICATION STATUS
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> SHOW LAST REVERT
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> ADOPT REPLICATION JOB <job_id>
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> CLEAR REPLICATION JOB REFERENCE
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> TEST REPLICATION SOURCE
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> SET REPLICATION SOURCE TENANT 'name'
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> SET REPLICATION TARGET TENANT <tenant_id>
+// ALTER VIRTUAL CLUSTER <virtual_cluster_spec> START REPLICATION OF <virtual_cluster_spec> ON 'url' [WITH opt[=value],...]
 alter_virtual_cluster_replication_stmt:
   ALTER virtual_cluster virtual_cluster_spec PAUSE REPLICATION
   {
@@ -7382,23 +7591,77 @@ alter_virtual_cluster_replication_stmt:
       Command: tree.ResumeJob,
     }
   }
-| ALTER virtual_cluster virtual_cluster_spec COMPLETE REPLICATION TO SYSTEM TIME a_expr
+| ALTER virtual_cluster virtual_cluster_spec RESUME REPLICATION WITH REFRESH_TOPOLOGY
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      Command: tree.ResumeJob,
+      RefreshTopology: true,
+    }
+  }
+| ALTER TENANT_ALL ALL PAUSE REPLICATION
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: &tree.TenantSpec{All: true},
+      Command: tree.PauseJob,
+    }
+  }
+| ALTER VIRTUAL CLUSTER_ALL ALL PAUSE REPLICATION
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: &tree.TenantSpec{All: true},
+      Command: tree.PauseJob,
+    }
+  }
+| ALTER TENANT_ALL ALL RESUME REPLICATION
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: &tree.TenantSpec{All: true},
+      Command: tree.ResumeJob,
+    }
+  }
+| ALTER VIRTUAL CLUSTER_ALL ALL RESUME REPLICATION
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: &tree.TenantSpec{All: true},
+      Command: tree.ResumeJob,
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec COMPLETE REPLICATION TO SYSTEM TIME a_expr opt_with_cutover_options
   {
     /* SKIP DOC */
     $$.val = &tree.AlterTenantReplication{
       TenantSpec: $3.tenantSpec(),
       Cutover: &tree.ReplicationCutoverTime{
         Timestamp: $9.expr(),
+        Options: *$10.replicationCutoverOptions(),
       },
     }
   }
-| ALTER virtual_cluster virtual_cluster_spec COMPLETE REPLICATION TO LATEST
+| ALTER virtual_cluster virtual_cluster_spec COMPLETE REPLICATION TO LATEST opt_with_cutover_options
   {
     /* SKIP DOC */
     $$.val = &tree.AlterTenantReplication{
       TenantSpec: $3.tenantSpec(),
       Cutover: &tree.ReplicationCutoverTime{
         Latest: true,
+        Options: *$8.replicationCutoverOptions(),
+      },
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec COMPLETE REPLICATION TO EVENT d_expr opt_with_cutover_options
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      Cutover: &tree.ReplicationCutoverTime{
+        Event: $8.expr(),
+        Options: *$9.replicationCutoverOptions(),
       },
     }
   }
@@ -7410,6 +7673,62 @@ alter_virtual_cluster_replication_stmt:
       Options: *$6.tenantReplicationOptions(),
     }
   }
+| ALTER virtual_cluster virtual_cluster_spec REFRESH REPLICATION STATUS
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      RefreshStatus: true,
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec SHOW LAST REVERT
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      ShowLastRevert: true,
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec ADOPT REPLICATION JOB a_expr
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      AdoptReplicationJob: $7.expr(),
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec CLEAR REPLICATION JOB REFERENCE
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      ClearReplicationJobReference: true,
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec TEST REPLICATION SOURCE
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      TestReplicationSource: true,
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec SET REPLICATION SOURCE TENANT d_expr
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      NewReplicationSourceTenantName: &tree.TenantSpec{IsName: true, Expr: $8.expr()},
+    }
+  }
+| ALTER virtual_cluster virtual_cluster_spec SET REPLICATION TARGET TENANT a_expr
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterTenantReplication{
+      TenantSpec: $3.tenantSpec(),
+      NewReplicationTargetTenantID: $8.expr(),
+    }
+  }
 | ALTER virtual_cluster virtual_cluster_spec START REPLICATION OF d_expr ON d_expr opt_with_replication_options
   {
     /* SKIP DOC */
@@ -7421,6 +7740,52 @@ alter_virtual_cluster_replication_stmt:
     }
   }
 
+opt_with_cutover_options:
+  WITH cutover_options_list
+  {
+    $$.val = $2.replicationCutoverOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.ReplicationCutoverOptions{}
+  }
+
+cutover_options_list:
+  // Require at least one option
+  cutover_options
+  {
+    $$.val = $1.replicationCutoverOptions()
+  }
+| cutover_options_list ',' cutover_options
+  {
+    if err := $1.replicationCutoverOptions().CombineWith($3.replicationCutoverOptions()); err != nil {
+      return setErr(sqllex, err)
+    }
+  }
+
+// List of valid cutover options.
+cutover_options:
+  ALLOW_DATA_LOSS
+  {
+    $$.val = &tree.ReplicationCutoverOptions{AllowDataLoss: true}
+  }
+| CLOCK_SKEW_TOLERANCE '=' d_expr
+  {
+    $$.val = &tree.ReplicationCutoverOptions{ClockSkewTolerance: $3.expr()}
+  }
+| DRAIN_BEFORE_CUTOVER
+  {
+    $$.val = &tree.ReplicationCutoverOptions{DrainBeforeCutover: true}
+  }
+| FORMAT '=' DECIMAL
+  {
+    $$.val = &tree.ReplicationCutoverOptions{Format: "DECIMAL"}
+  }
+| FORMAT '=' JSON
+  {
+    $$.val = &tree.ReplicationCutoverOptions{Format: "JSON"}
+  }
+
 
 // %Help: ALTER VIRTUAL CLUSTER SETTING - alter cluster setting overrides for virtual clusters
 // %Category: Group
@@ -7834,6 +8199,7 @@ show_stmt:
 | show_create_external_connections_stmt // EXTEND WITH HELP: SHOW CREATE EXTERNAL CONNECTIONS
 | show_local_or_virtual_cluster_csettings_stmt // EXTEND WITH HELP: SHOW CLUSTER SETTING
 | show_logical_replication_jobs_stmt	// EXTEND WITH HELP: SHOW LOGICAL REPLICATION JOBS
+| show_replication_stmt      // EXTEND WITH HELP: SHOW REPLICATION
 | show_databases_stmt        // EXTEND WITH HELP: SHOW DATABASES
 | show_enums_stmt            // EXTEND WITH HELP: SHOW ENUMS
 | show_external_connections_stmt // EXTEND WITH HELP: SHOW EXTERNAL CONNECTIONS
@@ -17480,8 +17846,10 @@ unreserved_keyword:
 | ACCESS
 | ADD
 | ADMIN
+| ADOPT
 | AFTER
 | AGGREGATE
+| ALLOW_DATA_LOSS
 | ALTER
 | ALWAYS
 | ASENSITIVE
@@ -17490,6 +17858,7 @@ unreserved_keyword:
 | ATOMIC
 | ATTRIBUTE
 | AUTOMATIC
+| AUTO_CUTOVER
 | AVAILABILITY
 | BACKUP
 | BACKUPS
@@ -17508,9 +17877,14 @@ unreserved_keyword:
 | CANCELQUERY
 | CAPABILITIES
 | CAPABILITY
+| CAPACITY
 | CASCADE
 | CHANGEFEED
+| CHECKPOINT_INTERVAL
 | CHECK_FILES
+| CLEAR
+| CLOCK
+| CLOCK_SKEW_TOLERANCE
 | CLOSE
 | CLUSTER
 | CLUSTERS
@@ -17564,15 +17938,19 @@ unreserved_keyword:
 | DISCARD
 | DOMAIN
 | DOUBLE
+| DRAIN_BEFORE_CUTOVER
 | DROP
 | EACH
 | ENCODING
 | ENCRYPTED
+| ENCRYPTION_KEY
 | ENCRYPTION_PASSPHRASE
 | ENCRYPTION_INFO_DIR
 | ENUM
 | ENUMS
 | ESCAPE
+| EVENT
+| EVENT_SINK
 | EXCLUDE
 | EXCLUDING
 | EXECUTE
@@ -17625,6 +18003,7 @@ unreserved_keyword:
 | HOLD
 | HOUR
 | IDENTITY
+| IDLE_TIMEOUT
 | IMMEDIATE
 | IMMEDIATELY
 | IMMUTABLE
@@ -17638,7 +18017,9 @@ unreserved_keyword:
 | INCREMENTAL_LOCATION
 | INDEX
 | INDEXES
+| INGEST_MODE
 | INHERITS
+| INITIAL_RETENTION
 | INJECT
 | INPUT
 | INSERT
@@ -17680,6 +18061,7 @@ unreserved_keyword:
 | MATCH
 | MATERIALIZED
 | MAXVALUE
+| MAX_PARTITION_STREAMS
 | MERGE
 | METHOD
 | MINUTE
@@ -17739,6 +18121,7 @@ unreserved_keyword:
 | OIDS
 | OLD
 | OLD_KMS
+| ON_ERROR
 | OPERATOR
 | OPT
 | OPTION
@@ -17756,6 +18139,7 @@ unreserved_keyword:
 | PASSWORD
 | PAUSE
 | PAUSED
+| PAUSE_ON_DISK_FULL
 | PER
 | PHYSICAL
 | PLACEMENT
@@ -17775,6 +18159,8 @@ unreserved_keyword:
 | PRIVILEGES
 | PROCEDURE
 | PROCEDURES
+| PTS
+| PTS_ADVANCE_INTERVAL
 | PUBLIC
 | PUBLICATION
 | QUERIES
@@ -17789,8 +18175,10 @@ unreserved_keyword:
 | RECURSIVE
 | REDACT
 | REF
+| REFERENCE
 | REFERENCING
 | REFRESH
+| REFRESH_TOPOLOGY
 | REGION
 | REGIONAL
 | REGIONS
@@ -17809,10 +18197,12 @@ unreserved_keyword:
 | RESTRICT
 | RESTRICTED
 | RESUME
+| RESUME_PARTITIONS
 | RETENTION
 | RETRY
 | RETURN
 | RETURNS
+| REVERT
 | REVISION_HISTORY
 | REVOKE
 | ROLE
@@ -17827,8 +18217,14 @@ unreserved_keyword:
 | SCHEDULES
 | SCHEMA_ONLY
 | SCROLL
+| SEED_FROM_BACKUP
+| SERVICE_MODE_ON_COMPLETE
 | SETTING
 | SETTINGS
+| SOURCE
+| SOURCE_CONN_POOL
+| SPAN_CONFIG
+| STATS
 | STATUS
 | SAVEPOINT
 | SCANS
@@ -17891,15 +18287,20 @@ unreserved_keyword:
 | SYSTEM
 | TABLES
 | TABLESPACE
+| TARGET
+| TARGET_NODES
 | TEMP
 | TEMPLATE
 | TEMPORARY
 | TENANT
 | TENANT_NAME
 | TENANTS
+| TEST
 | TESTING_RELOCATE
 | TEXT
+| THROUGHPUT
 | TIES
+| TOPOLOGY
 | TRACE
 | TRACING
 | TRANSACTION
@@ -17928,10 +18329,12 @@ unreserved_keyword:
 | USERS
 | VALID
 | VALIDATE
+| VALIDATE_ONLY
 | VALUE
 | VARIABLES
 | VARYING
 | VERIFY_BACKUP_TABLE_DATA
+| VERIFY_CHECKSUMS
 | VIEW
 | VIEWACTIVITY
 | VIEWACTIVITYREDACTED
@@ -17961,9 +18364,11 @@ bare_label_keywords:
 | ACTION
 | ADD
 | ADMIN
+| ADOPT
 | AFTER
 | AGGREGATE
 | ALL
+| ALLOW_DATA_LOSS
 | ALTER
 | ALWAYS
 | ANALYSE
@@ -17980,6 +18385,7 @@ bare_label_keywords:
 | ATTRIBUTE
 | AUTHORIZATION
 | AUTOMATIC
+| AUTO_CUTOVER
 | AVAILABILITY
 | BACKUP
 | BACKUPS
@@ -18004,13 +18410,18 @@ bare_label_keywords:
 | CANCELQUERY
 | CAPABILITIES
 | CAPABILITY
+| CAPACITY
 | CASCADE
 | CASE
 | CAST
 | CHANGEFEED
 | CHARACTERISTICS
 | CHECK
+| CHECKPOINT_INTERVAL
 | CHECK_FILES
+| CLEAR
+| CLOCK
+| CLOCK_SKEW_TOLERANCE
 | CLOSE
 | CLUSTER
 | CLUSTERS
@@ -18083,17 +18494,21 @@ bare_label_keywords:
 | DO
 | DOMAIN
 | DOUBLE
+| DRAIN_BEFORE_CUTOVER
 | DROP
 | EACH
 | ELSE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_INFO_DIR
+| ENCRYPTION_KEY
 | ENCRYPTION_PASSPHRASE
 | END
 | ENUM
 | ENUMS
 | ESCAPE
+| EVENT
+| EVENT_SINK
 | EXCLUDE
 | EXCLUDING
 | EXECUTE
@@ -18156,6 +18571,7 @@ bare_label_keywords:
 | HISTOGRAM
 | HOLD
 | IDENTITY
+| IDLE_TIMEOUT
 | IF
 | IFERROR
 | IFNULL
@@ -18179,8 +18595,10 @@ bare_label_keywords:
 | INDEX_AFTER_ORDER_BY_BEFORE_AT
 | INDEX_BEFORE_NAME_THEN_PAREN
 | INDEX_BEFORE_PAREN
+| INGEST_MODE
 | INHERITS
 | INITIALLY
+| INITIAL_RETENTION
 | INJECT
 | INNER
 | INOUT
@@ -18238,6 +18656,7 @@ bare_label_keywords:
 | MATCH
 | MATERIALIZED
 | MAXVALUE
+| MAX_PARTITION_STREAMS
 | MERGE
 | METHOD
 | MINVALUE
@@ -18300,6 +18719,7 @@ bare_label_keywords:
 | OLD
 | OLD_KMS
 | ONLY
+| ON_ERROR
 | OPERATOR
 | OPT
 | OPTION
@@ -18320,6 +18740,7 @@ bare_label_keywords:
 | PASSWORD
 | PAUSE
 | PAUSED
+| PAUSE_ON_DISK_FULL
 | PER
 | PHYSICAL
 | PLACEMENT
@@ -18344,6 +18765,8 @@ bare_label_keywords:
 | PRIVILEGES
 | PROCEDURE
 | PROCEDURES
+| PTS
+| PTS_ADVANCE_INTERVAL
 | PUBLIC
 | PUBLICATION
 | QUERIES
@@ -18359,9 +18782,11 @@ bare_label_keywords:
 | RECURSIVE
 | REDACT
 | REF
+| REFERENCE
 | REFERENCES
 | REFERENCING
 | REFRESH
+| REFRESH_TOPOLOGY
 | REGION
 | REGIONAL
 | REGIONS
@@ -18380,10 +18805,12 @@ bare_label_keywords:
 | RESTRICT
 | RESTRICTED
 | RESUME
+| RESUME_PARTITIONS
 | RETENTION
 | RETRY
 | RETURN
 | RETURNS
+| REVERT
 | REVISION_HISTORY
 | REVOKE
 | RIGHT
@@ -18409,12 +18836,14 @@ bare_label_keywords:
 | SEARCH
 | SECONDARY
 | SECURITY
+| SEED_FROM_BACKUP
 | SELECT
 | SEQUENCE
 | SEQUENCES
 | SERIALIZABLE
 | SERVER
 | SERVICE
+| SERVICE_MODE_ON_COMPLETE
 | SESSION
 | SESSIONS
 | SESSION_USER
@@ -18439,6 +18868,9 @@ bare_label_keywords:
 | SMALLINT
 | SNAPSHOT
 | SOME
+| SOURCE
+| SOURCE_CONN_POOL
+| SPAN_CONFIG
 | SPLIT
 | SQL
 | SQLLOGIN
@@ -18448,6 +18880,7 @@ bare_label_keywords:
 | STATEMENT
 | STATEMENTS
 | STATISTICS
+| STATS
 | STATUS
 | STDIN
 | STDOUT
@@ -18473,21 +18906,26 @@ bare_label_keywords:
 | TABLE
 | TABLES
 | TABLESPACE
+| TARGET
+| TARGET_NODES
 | TEMP
 | TEMPLATE
 | TEMPORARY
 | TENANT
 | TENANTS
 | TENANT_NAME
+| TEST
 | TESTING_RELOCATE
 | TEXT
 | THEN
 | THROTTLING
+| THROUGHPUT
 | TIES
 | TIME
 | TIMESTAMP
 | TIMESTAMPTZ
 | TIMETZ
+| TOPOLOGY
 | TRACE
 | TRACING
 | TRAILING
@@ -18522,6 +18960,7 @@ bare_label_keywords:
 | USING
 | VALID
 | VALIDATE
+| VALIDATE_ONLY
 | VALUE
 | VALUES
 | VARBIT
@@ -18530,6 +18969,7 @@ bare_label_keywords:
 | VARIADIC
 | VECTOR
 | VERIFY_BACKUP_TABLE_DATA
+| VERIFY_CHECKSUMS
 | VIEW
 | VIEWACTIVITY
 | VIEWACTIVITYREDACTED
@@ -10,10 +10,113 @@
 
 package tree
 
+import "github.com/cockroachdb/errors"
+
 // ReplicationCutoverTime represent the user-specified cutover time
 type ReplicationCutoverTime struct {
 	Timestamp Expr
 	Latest    bool
+	// Event, if set, is the name of a recorded event whose time is used as the
+	// cutover time.
+	Event Expr
+
+	Options ReplicationCutoverOptions
+}
+
+// ReplicationCutoverOptions are the options of an ALTER VIRTUAL CLUSTER ...
+// COMPLETE REPLICATION statement.
+type ReplicationCutoverOptions struct {
+	// AllowDataLoss acknowledges that the cutover time may be far behind the
+	// replicated time, discarding the data replicated in between.
+	AllowDataLoss bool
+	// ClockSkewTolerance, if set, is how far below the earliest retained
+	// timestamp the cutover time may be, to accommodate known clock skew
+	// between the systems that chose it and this cluster.
+	ClockSkewTolerance Expr
+	// DrainBeforeCutover requests that the data the consumer job has received
+	// but not yet applied be applied before it reverts to the cutover time.
+	DrainBeforeCutover bool
+	// Format, if set, is the format of the cutover's result: DECIMAL, the
+	// default, returns the bare cutover time, whereas JSON returns an object
+	// describing the cutover.
+	Format string
+}
+
+var _ NodeFormatter = &ReplicationCutoverOptions{}
+
+// Format implements the NodeFormatter interface.
+func (o *ReplicationCutoverOptions) Format(ctx *FmtCtx) {
+	var addSep bool
+	maybeAddSep := func() {
+		if addSep {
+			ctx.WriteString(", ")
+		}
+		addSep = true
+	}
+	if o.AllowDataLoss {
+		maybeAddSep()
+		ctx.WriteString("ALLOW_DATA_LOSS")
+	}
+	if o.ClockSkewTolerance != nil {
+		maybeAddSep()
+		ctx.WriteString("CLOCK_SKEW_TOLERANCE = ")
+		ctx.FormatNode(o.ClockSkewTolerance)
+	}
+	if o.DrainBeforeCutover {
+		maybeAddSep()
+		ctx.WriteString("DRAIN_BEFORE_CUTOVER")
+	}
+	if o.Format != "" {
+		maybeAddSep()
+		ctx.WriteString("FORMAT = ")
+		ctx.WriteString(o.Format)
+	}
+}
+
+// CombineWith merges other options into o.
+func (o *ReplicationCutoverOptions) CombineWith(other *ReplicationCutoverOptions) error {
+	if o.AllowDataLoss {
+		if other.AllowDataLoss {
+			return errors.New("ALLOW_DATA_LOSS option specified multiple times")
+		}
+	} else {
+		o.AllowDataLoss = other.AllowDataLoss
+	}
+
+	if o.ClockSkewTolerance != nil {
+		if other.ClockSkewTolerance != nil {
+			return errors.New("CLOCK_SKEW_TOLERANCE option specified multiple times")
+		}
+	} else {
+		o.ClockSkewTolerance = other.ClockSkewTolerance
+	}
+
+	if o.DrainBeforeCutover {
+		if other.DrainBeforeCutover {
+			return errors.New("DRAIN_BEFORE_CUTOVER option specified multiple times")
+		}
+	} else {
+		o.DrainBeforeCutover = other.DrainBeforeCutover
+	}
+
+	if o.Format != "" {
+		if other.Format != "" {
+			return errors.New("FORMAT option specified multiple times")
+		}
+	} else {
+		o.Format = other.Format
+	}
+
+	return nil
+}
+
+// IsDefault returns true if this options object is empty.
+func (o ReplicationCutoverOptions) IsDefault() bool {
+	options := ReplicationCutoverOptions{}
+	return o.AllowDataLoss == options.AllowDataLoss &&
+		o.ClockSkewTolerance == options.ClockSkewTolerance &&
+		o.DrainBeforeCutover == options.DrainBeforeCutover &&
+		o.Format == options.Format
 }
 
 // AlterTenantReplication represents an ALTER VIRTUAL CLUSTER REPLICATION statement.
@@ -25,6 +128,37 @@ type AlterTenantReplication struct {
 	// ReplicationSourceAddress is the address of the source cluster that we are
 	// replicating data from.
 	ReplicationSourceAddress Expr
+	// RefreshStatus is set for ALTER VIRTUAL CLUSTER ... REFRESH REPLICATION
+	// STATUS, which asks the replication job to persist its replication status
+	// immediately.
+	RefreshStatus bool
+	// NewReplicationSourceTenantName is set for ALTER VIRTUAL CLUSTER ... SET
+	// REPLICATION SOURCE TENANT, which updates the name of the source tenant
+	// the replication job connects to, e.g. after it was renamed on the source.
+	NewReplicationSourceTenantName *TenantSpec
+	// NewReplicationTargetTenantID is set for ALTER VIRTUAL CLUSTER ... SET
+	// REPLICATION TARGET TENANT, which re-targets the tenant's replication job
+	// at the tenant with the given ID, e.g. after the tenant was renumbered.
+	NewReplicationTargetTenantID Expr
+	// ShowLastRevert is set for ALTER VIRTUAL CLUSTER ... SHOW LAST REVERT,
+	// which returns the time to which the tenant's data was last reverted.
+	ShowLastRevert bool
+	// AdoptReplicationJob is set for ALTER VIRTUAL CLUSTER ... ADOPT
+	// REPLICATION JOB, which re-points the given replication consumer job at
+	// the tenant, e.g. when re-homing a replication stream.
+	AdoptReplicationJob Expr
+	// ClearReplicationJobReference is set for ALTER VIRTUAL CLUSTER ... CLEAR
+	// REPLICATION JOB REFERENCE, which removes the tenant's reference to a
+	// replication consumer job that no longer exists.
+	ClearReplicationJobReference bool
+	// TestReplicationSource is set for ALTER VIRTUAL CLUSTER ... TEST
+	// REPLICATION SOURCE, which checks that the source of the tenant's
+	// replication job can be reached.
+	TestReplicationSource bool
+	// RefreshTopology is set for ALTER VIRTUAL CLUSTER ... RESUME REPLICATION
+	// WITH REFRESH_TOPOLOGY, which makes the resumed replication job re-query
+	// the partition layout of the source rather than reuse the stored one.
+	RefreshTopology bool
 
 	Options TenantReplicationOptions
 }
@@ -40,10 +174,17 @@ func (n *AlterTenantReplication) Format(ctx *FmtCtx) {
 		ctx.WriteString("COMPLETE REPLICATION TO ")
 		if n.Cutover.Latest {
 			ctx.WriteString("LATEST")
+		} else if n.Cutover.Event != nil {
+			ctx.WriteString("EVENT ")
+			ctx.FormatNode(n.Cutover.Event)
 		} else {
 			ctx.WriteString("SYSTEM TIME ")
 			ctx.FormatNode(n.Cutover.Timestamp)
 		}
+		if !n.Cutover.Options.IsDefault() {
+			ctx.WriteString(" WITH ")
+			ctx.FormatNode(&n.Cutover.Options)
+		}
 	} else if n.ReplicationSourceTenantName != nil {
 		ctx.WriteString("START REPLICATION OF ")
 		ctx.FormatNode(n.ReplicationSourceTenantName)
@@ -64,9 +205,29 @@ func (n *AlterTenantReplication) Format(ctx *FmtCtx) {
 	} else if !n.Options.IsDefault() {
 		ctx.WriteString("SET REPLICATION ")
 		ctx.FormatNode(&n.Options)
+	} else if n.RefreshStatus {
+		ctx.WriteString("REFRESH REPLICATION STATUS")
+	} else if n.ShowLastRevert {
+		ctx.WriteString("SHOW LAST REVERT")
+	} else if n.AdoptReplicationJob != nil {
+		ctx.WriteString("ADOPT REPLICATION JOB ")
+		ctx.FormatNode(n.AdoptReplicationJob)
+	} else if n.ClearReplicationJobReference {
+		ctx.WriteString("CLEAR REPLICATION JOB REFERENCE")
+	} else if n.TestReplicationSource {
+		ctx.WriteString("TEST REPLICATION SOURCE")
+	} else if n.NewReplicationSourceTenantName != nil {
+		ctx.WriteString("SET REPLICATION SOURCE TENANT ")
+		ctx.FormatNode(n.NewReplicationSourceTenantName)
+	} else if n.NewReplicationTargetTenantID != nil {
+		ctx.WriteString("SET REPLICATION TARGET TENANT ")
+		ctx.FormatNode(n.NewReplicationTargetTenantID)
 	} else if n.Command == PauseJob || n.Command == ResumeJob {
 		ctx.WriteString(JobCommandToStatement[n.Command])
 		ctx.WriteString(" REPLICATION")
+		if n.RefreshTopology {
+			ctx.WriteString(" WITH REFRESH_TOPOLOGY")
+		}
 	}
 }
 
@@ -2217,8 +2217,69 @@ type CreateTenantFromReplication struct {
 
 // TenantReplicationOptions  options for the CREATE/ALTER VIRTUAL CLUSTER FROM REPLICATION command.
 type TenantReplicationOptions struct {
-	Retention        Expr
+	Retention Expr
+	// RetentionFromZone, if set, is the name of the zone whose GC TTL is used
+	// as the retention, i.e. RETENTION = FROM ZONE <name>. It is mutually
+	// exclusive with Retention.
+	RetentionFromZone Expr
+	// InitialRetention, if set, is the retention used in place of Retention
+	// until the replicated time first catches up with the source.
+	InitialRetention Expr
 	ExpirationWindow Expr
+	SeedFromBackup   Expr
+	Priority         Expr
+	ResumePartitions Expr
+	Owner            Expr
+	// ServiceModeOnComplete, if set, is the service mode the destination
+	// tenant is put in once the replication cutover completes.
+	ServiceModeOnComplete Expr
+	// PauseOnDiskFull, if set, is the destination store disk usage percentage
+	// above which ingestion is paused.
+	PauseOnDiskFull Expr
+	// MaxPartitionStreams, if set, is the maximum number of partition streams
+	// the consumer keeps open to the source concurrently.
+	MaxPartitionStreams Expr
+	// PTSAdvanceInterval, if set, is how often the consumer advances the
+	// protected timestamp record as the replicated time moves.
+	PTSAdvanceInterval Expr
+	// VerifyChecksums, if set, controls whether the consumer periodically
+	// verifies the consistency of the replicated data against the source.
+	VerifyChecksums Expr
+	// EventSink, if set, is the URI of the sink to which the lifecycle events
+	// of the replication stream are to be published.
+	EventSink Expr
+	// TargetNodes, if set, is a comma-separated list of the IDs of the
+	// destination nodes to which the ingestion processors are confined.
+	TargetNodes Expr
+	// OnError, if set, is what the replication job does when ingestion hits an
+	// error it cannot retry: 'pause' it for intervention, or 'fail' it.
+	OnError Expr
+	// AutoCutover, if set, is the time to which the replication job cuts over
+	// on its own once its replicated time reaches it.
+	AutoCutover Expr
+	// IdleTimeout, if set, is how long the replication job waits for its
+	// replicated time to advance before pausing itself.
+	IdleTimeout Expr
+	// SpanConfig, if set, is whether the destination virtual cluster
+	// 'inherit's the span configurations of the source, or keeps its 'local'
+	// ones.
+	SpanConfig Expr
+	// EncryptionKey, if set, references the managed key with which the
+	// replication stream is encrypted. It is redacted when formatted, unless
+	// passwords are shown.
+	EncryptionKey Expr
+	// CheckpointInterval, if set, is how often the replication job persists
+	// the checkpoint of its ingestion frontier.
+	CheckpointInterval Expr
+	// SourceConnPool, if set, is the number of connections to the source the
+	// consumer pools.
+	SourceConnPool Expr
+	// IngestMode, if set, selects whether the replication job ingests raw KVs
+	// or applies them row by row.
+	IngestMode Expr
+	// ValidateOnly, if set, makes ALTER VIRTUAL CLUSTER ... START REPLICATION
+	// only validate that replication could be started, without starting it.
+	ValidateOnly bool
 }
 
 var _ NodeFormatter = &TenantReplicationOptions{}
@@ -2262,41 +2323,123 @@ func (o *TenantReplicationOptions) Format(ctx *FmtCtx) {
 		}
 		addSep = true
 	}
-	if o.Retention != nil {
-		maybeAddSep()
-		ctx.WriteString("RETENTION = ")
-		_, canOmitParentheses := o.Retention.(alreadyDelimitedAsSyntacticDExpr)
+	formatValue := func(expr Expr) {
+		_, canOmitParentheses := expr.(alreadyDelimitedAsSyntacticDExpr)
 		if !canOmitParentheses {
 			ctx.WriteByte('(')
 		}
-		ctx.FormatNode(o.Retention)
+		ctx.FormatNode(expr)
 		if !canOmitParentheses {
 			ctx.WriteByte(')')
 		}
 	}
+	formatOption := func(name string, expr Expr) {
+		maybeAddSep()
+		ctx.WriteString(name)
+		ctx.WriteString(" = ")
+		formatValue(expr)
+	}
+	if o.Retention != nil {
+		formatOption("RETENTION", o.Retention)
+	}
+	if o.RetentionFromZone != nil {
+		maybeAddSep()
+		ctx.WriteString("RETENTION = FROM ZONE ")
+		formatValue(o.RetentionFromZone)
+	}
+	if o.InitialRetention != nil {
+		formatOption("INITIAL_RETENTION", o.InitialRetention)
+	}
 	if o.ExpirationWindow != nil {
+		formatOption("EXPIRATION WINDOW", o.ExpirationWindow)
+	}
+	if o.SeedFromBackup != nil {
+		formatOption("SEED_FROM_BACKUP", o.SeedFromBackup)
+	}
+	if o.Priority != nil {
+		formatOption("PRIORITY", o.Priority)
+	}
+	if o.ResumePartitions != nil {
+		formatOption("RESUME_PARTITIONS", o.ResumePartitions)
+	}
+	if o.Owner != nil {
+		formatOption("OWNER", o.Owner)
+	}
+	if o.ServiceModeOnComplete != nil {
+		formatOption("SERVICE_MODE_ON_COMPLETE", o.ServiceModeOnComplete)
+	}
+	if o.PauseOnDiskFull != nil {
+		formatOption("PAUSE_ON_DISK_FULL", o.PauseOnDiskFull)
+	}
+	if o.MaxPartitionStreams != nil {
+		formatOption("MAX_PARTITION_STREAMS", o.MaxPartitionStreams)
+	}
+	if o.PTSAdvanceInterval != nil {
+		formatOption("PTS_ADVANCE_INTERVAL", o.PTSAdvanceInterval)
+	}
+	if o.VerifyChecksums != nil {
+		formatOption("VERIFY_CHECKSUMS", o.VerifyChecksums)
+	}
+	if o.EventSink != nil {
+		formatOption("EVENT_SINK", o.EventSink)
+	}
+	if o.TargetNodes != nil {
+		formatOption("TARGET_NODES", o.TargetNodes)
+	}
+	if o.OnError != nil {
+		formatOption("ON_ERROR", o.OnError)
+	}
+	if o.AutoCutover != nil {
+		formatOption("AUTO_CUTOVER", o.AutoCutover)
+	}
+	if o.IdleTimeout != nil {
+		formatOption("IDLE_TIMEOUT", o.IdleTimeout)
+	}
+	if o.SpanConfig != nil {
+		formatOption("SPAN_CONFIG", o.SpanConfig)
+	}
+	if o.EncryptionKey != nil {
 		maybeAddSep()
-		ctx.WriteString("EXPIRATION WINDOW = ")
-		_, canOmitParentheses := o.ExpirationWindow.(alreadyDelimitedAsSyntacticDExpr)
-		if !canOmitParentheses {
-			ctx.WriteByte('(')
-		}
-		ctx.FormatNode(o.ExpirationWindow)
-		if !canOmitParentheses {
-			ctx.WriteByte(')')
+		ctx.WriteString("ENCRYPTION_KEY = ")
+		if ctx.flags.HasFlags(FmtShowPasswords) {
+			formatValue(o.EncryptionKey)
+		} else {
+			ctx.WriteString(PasswordSubstitution)
 		}
 	}
+	if o.CheckpointInterval != nil {
+		formatOption("CHECKPOINT_INTERVAL", o.CheckpointInterval)
+	}
+	if o.SourceConnPool != nil {
+		formatOption("SOURCE_CONN_POOL", o.SourceConnPool)
+	}
+	if o.IngestMode != nil {
+		formatOption("INGEST_MODE", o.IngestMode)
+	}
+	if o.ValidateOnly {
+		maybeAddSep()
+		ctx.WriteString("VALIDATE_ONLY")
+	}
 }
 
 // CombineWith merges other TenantReplicationOptions into this struct.
 // An error is returned if the same option merged multiple times.
 func (o *TenantReplicationOptions) CombineWith(other *TenantReplicationOptions) error {
-	if o.Retention != nil {
-		if other.Retention != nil {
+	if o.Retention != nil || o.RetentionFromZone != nil {
+		if other.Retention != nil || other.RetentionFromZone != nil {
 			return errors.New("RETENTION option specified multiple times")
 		}
 	} else {
 		o.Retention = other.Retention
+		o.RetentionFromZone = other.RetentionFromZone
+	}
+
+	if o.InitialRetention != nil {
+		if other.InitialRetention != nil {
+			return errors.New("INITIAL_RETENTION option specified multiple times")
+		}
+	} else {
+		o.InitialRetention = other.InitialRetention
 	}
 
 	if o.ExpirationWindow != nil {
@@ -2307,6 +2450,166 @@ func (o *TenantReplicationOptions) CombineWith(other *TenantReplicationOptions)
 		o.ExpirationWindow = other.ExpirationWindow
 	}
 
+	if o.SeedFromBackup != nil {
+		if other.SeedFromBackup != nil {
+			return errors.New("SEED_FROM_BACKUP option specified multiple times")
+		}
+	} else {
+		o.SeedFromBackup = other.SeedFromBackup
+	}
+
+	if o.Priority != nil {
+		if other.Priority != nil {
+			return errors.New("PRIORITY option specified multiple times")
+		}
+	} else {
+		o.Priority = other.Priority
+	}
+
+	if o.ResumePartitions != nil {
+		if other.ResumePartitions != nil {
+			return errors.New("RESUME_PARTITIONS option specified multiple times")
+		}
+	} else {
+		o.ResumePartitions = other.ResumePartitions
+	}
+
+	if o.Owner != nil {
+		if other.Owner != nil {
+			return errors.New("OWNER option specified multiple times")
+		}
+	} else {
+		o.Owner = other.Owner
+	}
+
+	if o.ServiceModeOnComplete != nil {
+		if other.ServiceModeOnComplete != nil {
+			return errors.New("SERVICE_MODE_ON_COMPLETE option specified multiple times")
+		}
+	} else {
+		o.ServiceModeOnComplete = other.ServiceModeOnComplete
+	}
+
+	if o.PauseOnDiskFull != nil {
+		if other.PauseOnDiskFull != nil {
+			return errors.New("PAUSE_ON_DISK_FULL option specified multiple times")
+		}
+	} else {
+		o.PauseOnDiskFull = other.PauseOnDiskFull
+	}
+
+	if o.MaxPartitionStreams != nil {
+		if other.MaxPartitionStreams != nil {
+			return errors.New("MAX_PARTITION_STREAMS option specified multiple times")
+		}
+	} else {
+		o.MaxPartitionStreams = other.MaxPartitionStreams
+	}
+
+	if o.PTSAdvanceInterval != nil {
+		if other.PTSAdvanceInterval != nil {
+			return errors.New("PTS_ADVANCE_INTERVAL option specified multiple times")
+		}
+	} else {
+		o.PTSAdvanceInterval = other.PTSAdvanceInterval
+	}
+
+	if o.VerifyChecksums != nil {
+		if other.VerifyChecksums != nil {
+			return errors.New("VERIFY_CHECKSUMS option specified multiple times")
+		}
+	} else {
+		o.VerifyChecksums = other.VerifyChecksums
+	}
+
+	if o.EventSink != nil {
+		if other.EventSink != nil {
+			return errors.New("EVENT_SINK option specified multiple times")
+		}
+	} else {
+		o.EventSink = other.EventSink
+	}
+
+	if o.TargetNodes != nil {
+		if other.TargetNodes != nil {
+			return errors.New("TARGET_NODES option specified multiple times")
+		}
+	} else {
+		o.TargetNodes = other.TargetNodes
+	}
+
+	if o.OnError != nil {
+		if other.OnError != nil {
+			return errors.New("ON_ERROR option specified multiple times")
+		}
+	} else {
+		o.OnError = other.OnError
+	}
+
+	if o.AutoCutover != nil {
+		if other.AutoCutover != nil {
+			return errors.New("AUTO_CUTOVER option specified multiple times")
+		}
+	} else {
+		o.AutoCutover = other.AutoCutover
+	}
+
+	if o.IdleTimeout != nil {
+		if other.IdleTimeout != nil {
+			return errors.New("IDLE_TIMEOUT option specified multiple times")
+		}
+	} else {
+		o.IdleTimeout = other.IdleTimeout
+	}
+
+	if o.SpanConfig != nil {
+		if other.SpanConfig != nil {
+			return errors.New("SPAN_CONFIG option specified multiple times")
+		}
+	} else {
+		o.SpanConfig = other.SpanConfig
+	}
+
+	if o.EncryptionKey != nil {
+		if other.EncryptionKey != nil {
+			return errors.New("ENCRYPTION_KEY option specified multiple times")
+		}
+	} else {
+		o.EncryptionKey = other.EncryptionKey
+	}
+
+	if o.CheckpointInterval != nil {
+		if other.CheckpointInterval != nil {
+			return errors.New("CHECKPOINT_INTERVAL option specified multiple times")
+		}
+	} else {
+		o.CheckpointInterval = other.CheckpointInterval
+	}
+
+	if o.SourceConnPool != nil {
+		if other.SourceConnPool != nil {
+			return errors.New("SOURCE_CONN_POOL option specified multiple times")
+		}
+	} else {
+		o.SourceConnPool = other.SourceConnPool
+	}
+
+	if o.IngestMode != nil {
+		if other.IngestMode != nil {
+			return errors.New("INGEST_MODE option specified multiple times")
+		}
+	} else {
+		o.IngestMode = other.IngestMode
+	}
+
+	if o.ValidateOnly {
+		if other.ValidateOnly {
+			return errors.New("VALIDATE_ONLY option specified multiple times")
+		}
+	} else {
+		o.ValidateOnly = other.ValidateOnly
+	}
+
 	return nil
 }
 
@@ -2314,7 +2617,29 @@ func (o *TenantReplicationOptions) CombineWith(other *TenantReplicationOptions)
 func (o TenantReplicationOptions) IsDefault() bool {
 	options := TenantReplicationOptions{}
 	return o.Retention == options.Retention &&
-		o.ExpirationWindow == options.ExpirationWindow
+		o.RetentionFromZone == options.RetentionFromZone &&
+		o.InitialRetention == options.InitialRetention &&
+		o.ExpirationWindow == options.ExpirationWindow &&
+		o.SeedFromBackup == options.SeedFromBackup &&
+		o.Priority == options.Priority &&
+		o.ResumePartitions == options.ResumePartitions &&
+		o.Owner == options.Owner &&
+		o.ServiceModeOnComplete == options.ServiceModeOnComplete &&
+		o.PauseOnDiskFull == options.PauseOnDiskFull &&
+		o.MaxPartitionStreams == options.MaxPartitionStreams &&
+		o.PTSAdvanceInterval == options.PTSAdvanceInterval &&
+		o.VerifyChecksums == options.VerifyChecksums &&
+		o.EventSink == options.EventSink &&
+		o.TargetNodes == options.TargetNodes &&
+		o.OnError == options.OnError &&
+		o.AutoCutover == options.AutoCutover &&
+		o.IdleTimeout == options.IdleTimeout &&
+		o.SpanConfig == options.SpanConfig &&
+		o.EncryptionKey == options.EncryptionKey &&
+		o.CheckpointInterval == options.CheckpointInterval &&
+		o.SourceConnPool == options.SourceConnPool &&
+		o.IngestMode == options.IngestMode &&
+		o.ValidateOnly == options.ValidateOnly
 }
 
 func (o TenantReplicationOptions) ExpirationWindowSet() bool {
@@ -1275,6 +1275,72 @@ func (node *ShowTenant) Format(ctx *FmtCtx) {
 	}
 }
 
+// ShowTenantReplicationKind identifies what a SHOW REPLICATION statement
+// displays.
+type ShowTenantReplicationKind int
+
+const (
+	// ShowReplicationStats displays the ingestion statistics of the replication
+	// job.
+	ShowReplicationStats ShowTenantReplicationKind = iota
+	// ShowReplicationClock displays the replicated time of the replication job
+	// along with the window of times it can be cut over to.
+	ShowReplicationClock
+	// ShowReplicationSettings displays the replication-related cluster settings
+	// that were in effect when the replication job was created.
+	ShowReplicationSettings
+	// ShowReplicationOptions displays the replication options currently in
+	// effect for the replication job.
+	ShowReplicationOptions
+	// ShowReplicationPTS displays the protected timestamp record of the
+	// replication job.
+	ShowReplicationPTS
+	// ShowReplicationThroughput displays the rate at which the replication job
+	// is currently ingesting data.
+	ShowReplicationThroughput
+	// ShowReplicationCapacity displays how many more replication jobs the
+	// cluster can run. It is not specific to a virtual cluster.
+	ShowReplicationCapacity
+	// ShowReplicationTopology displays the producer partitions the replication
+	// job is tracking.
+	ShowReplicationTopology
+)
+
+var showTenantReplicationKindNames = [...]string{
+	ShowReplicationStats:      "STATS",
+	ShowReplicationClock:      "CLOCK",
+	ShowReplicationSettings:   "SETTINGS",
+	ShowReplicationOptions:    "OPTIONS",
+	ShowReplicationPTS:        "PTS",
+	ShowReplicationThroughput: "THROUGHPUT",
+	ShowReplicationCapacity:   "CAPACITY",
+	ShowReplicationTopology:   "TOPOLOGY",
+}
+
+// String implements the fmt.Stringer interface.
+func (k ShowTenantReplicationKind) String() string {
+	return showTenantReplicationKindNames[k]
+}
+
+// ShowTenantReplication represents a SHOW REPLICATION ... FOR VIRTUAL CLUSTER
+// statement.
+type ShowTenantReplication struct {
+	Kind ShowTenantReplicationKind
+	// TenantSpec is nil for the kinds that are not specific to a virtual
+	// cluster, i.e. SHOW REPLICATION CAPACITY.
+	TenantSpec *TenantSpec
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowTenantReplication) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW REPLICATION ")
+	ctx.WriteString(node.Kind.String())
+	if node.TenantSpec != nil {
+		ctx.WriteString(" FOR VIRTUAL CLUSTER ")
+		ctx.FormatNode(node.TenantSpec)
+	}
+}
+
 // ShowLogicalReplicationJobsOptions represents the WITH clause in SHOW LOGICAL REPLICATION JOBS.
 type ShowLogicalReplicationJobsOptions struct {
 	WithDetails bool
@@ -254,6 +254,7 @@ var _ CCLOnlyStatement = &Export{}
 var _ CCLOnlyStatement = &ScheduledBackup{}
 var _ CCLOnlyStatement = &CreateTenantFromReplication{}
 var _ CCLOnlyStatement = &CreateLogicalReplicationStream{}
+var _ CCLOnlyStatement = &ShowTenantReplication{}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterChangefeed) StatementReturnType() StatementReturnType { return Rows }
@@ -2013,6 +2014,17 @@ func (*ShowTenant) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowTenant) StatementTag() string { return "SHOW VIRTUAL CLUSTER" }
 
+// StatementReturnType implements the Statement interface.
+func (*ShowTenantReplication) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowTenantReplication) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowTenantReplication) StatementTag() string { return "SHOW REPLICATION" }
+
+func (*ShowTenantReplication) cclOnlyStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*ShowRoutines) StatementReturnType() StatementReturnType { return Rows }
 
@@ -2504,6 +2516,7 @@ func (n *ShowSyntax) String() string                          { return AsString(
 func (n *ShowTableStats) String() string                      { return AsString(n) }
 func (n *ShowTables) String() string                          { return AsString(n) }
 func (n *ShowTenant) String() string                          { return AsString(n) }
+func (n *ShowTenantReplication) String() string               { return AsString(n) }
 func (n *ShowTypes) String() string                           { return AsString(n) }
 func (n *ShowTraceForSession) String() string                 { return AsString(n) }
 func (n *ShowTransactionStatus) String() string               { return AsString(n) }
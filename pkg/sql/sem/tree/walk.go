@@ -1035,6 +1035,24 @@ func (n *AlterTenantReplication) walkStmt(v Visitor) Statement {
 			ret.Cutover.Timestamp = e
 		}
 	}
+	if n.Cutover != nil && n.Cutover.Event != nil {
+		e, changed := WalkExpr(v, n.Cutover.Event)
+		if changed {
+			if ret == n {
+				ret = n.copyNode()
+			}
+			ret.Cutover.Event = e
+		}
+	}
+	if n.Cutover != nil && n.Cutover.Options.ClockSkewTolerance != nil {
+		e, changed := WalkExpr(v, n.Cutover.Options.ClockSkewTolerance)
+		if changed {
+			if ret == n {
+				ret = n.copyNode()
+			}
+			ret.Cutover.Options.ClockSkewTolerance = e
+		}
+	}
 	if n.ReplicationSourceAddress != nil {
 		e, changed := WalkExpr(v, n.ReplicationSourceAddress)
 		if changed {
@@ -1044,32 +1062,47 @@ func (n *AlterTenantReplication) walkStmt(v Visitor) Statement {
 			ret.ReplicationSourceAddress = e
 		}
 	}
-	if n.ReplicationSourceTenantName != nil {
-		ts, changed := walkTenantSpec(v, n.ReplicationSourceTenantName)
+	if n.AdoptReplicationJob != nil {
+		e, changed := WalkExpr(v, n.AdoptReplicationJob)
 		if changed {
 			if ret == n {
 				ret = n.copyNode()
 			}
-			ret.TenantSpec = ts
+			ret.AdoptReplicationJob = e
+		}
+	}
+	if n.NewReplicationTargetTenantID != nil {
+		e, changed := WalkExpr(v, n.NewReplicationTargetTenantID)
+		if changed {
+			if ret == n {
+				ret = n.copyNode()
+			}
+			ret.NewReplicationTargetTenantID = e
 		}
 	}
-	if n.Options.Retention != nil {
-		e, changed := WalkExpr(v, n.Options.Retention)
+	if n.ReplicationSourceTenantName != nil {
+		ts, changed := walkTenantSpec(v, n.ReplicationSourceTenantName)
 		if changed {
 			if ret == n {
 				ret = n.copyNode()
 			}
-			ret.Options.Retention = e
+			ret.TenantSpec = ts
 		}
 	}
-	if n.Options.ExpirationWindow != nil {
-		e, changed := WalkExpr(v, n.Options.ExpirationWindow)
+	if n.NewReplicationSourceTenantName != nil {
+		ts, changed := walkTenantSpec(v, n.NewReplicationSourceTenantName)
 		if changed {
 			if ret == n {
 				ret = n.copyNode()
 			}
-			ret.Options.ExpirationWindow = e
+			ret.NewReplicationSourceTenantName = ts
+		}
+	}
+	if opts, changed := walkTenantReplicationOptions(v, n.Options); changed {
+		if ret == n {
+			ret = n.copyNode()
 		}
+		ret.Options = opts
 	}
 	return ret
 }
@@ -1103,23 +1136,73 @@ func (n *CreateTenantFromReplication) walkStmt(v Visitor) Statement {
 		}
 		ret.ReplicationSourceAddress = e
 	}
-	if n.Options.Retention != nil {
-		e, changed := WalkExpr(v, n.Options.Retention)
-		if changed {
-			if ret == n {
-				ret = n.copyNode()
-			}
-			ret.Options.Retention = e
+	if opts, changed := walkTenantReplicationOptions(v, n.Options); changed {
+		if ret == n {
+			ret = n.copyNode()
 		}
+		ret.Options = opts
 	}
-	if n.Options.ExpirationWindow != nil {
-		e, changed := WalkExpr(v, n.Options.ExpirationWindow)
-		if changed {
-			if ret == n {
-				ret = n.copyNode()
-			}
-			ret.Options.ExpirationWindow = e
-		}
+	return ret
+}
+
+// walkTenantReplicationOptions walks the expressions of the given
+// TenantReplicationOptions, returning a copy if any of them changed.
+func walkTenantReplicationOptions(
+	v Visitor, o TenantReplicationOptions,
+) (TenantReplicationOptions, bool) {
+	ret := o
+	var anyChanged bool
+	walkOption := func(expr Expr, set func(Expr)) {
+		if expr == nil {
+			return
+		}
+		if e, changed := WalkExpr(v, expr); changed {
+			set(e)
+			anyChanged = true
+		}
+	}
+	walkOption(o.Retention, func(e Expr) { ret.Retention = e })
+	walkOption(o.RetentionFromZone, func(e Expr) { ret.RetentionFromZone = e })
+	walkOption(o.InitialRetention, func(e Expr) { ret.InitialRetention = e })
+	walkOption(o.ExpirationWindow, func(e Expr) { ret.ExpirationWindow = e })
+	walkOption(o.SeedFromBackup, func(e Expr) { ret.SeedFromBackup = e })
+	walkOption(o.Priority, func(e Expr) { ret.Priority = e })
+	walkOption(o.ResumePartitions, func(e Expr) { ret.ResumePartitions = e })
+	walkOption(o.Owner, func(e Expr) { ret.Owner = e })
+	walkOption(o.ServiceModeOnComplete, func(e Expr) { ret.ServiceModeOnComplete = e })
+	walkOption(o.PauseOnDiskFull, func(e Expr) { ret.PauseOnDiskFull = e })
+	walkOption(o.MaxPartitionStreams, func(e Expr) { ret.MaxPartitionStreams = e })
+	walkOption(o.PTSAdvanceInterval, func(e Expr) { ret.PTSAdvanceInterval = e })
+	walkOption(o.VerifyChecksums, func(e Expr) { ret.VerifyChecksums = e })
+	walkOption(o.EventSink, func(e Expr) { ret.EventSink = e })
+	walkOption(o.TargetNodes, func(e Expr) { ret.TargetNodes = e })
+	walkOption(o.OnError, func(e Expr) { ret.OnError = e })
+	walkOption(o.AutoCutover, func(e Expr) { ret.AutoCutover = e })
+	walkOption(o.IdleTimeout, func(e Expr) { ret.IdleTimeout = e })
+	walkOption(o.SpanConfig, func(e Expr) { ret.SpanConfig = e })
+	walkOption(o.EncryptionKey, func(e Expr) { ret.EncryptionKey = e })
+	walkOption(o.CheckpointInterval, func(e Expr) { ret.CheckpointInterval = e })
+	walkOption(o.SourceConnPool, func(e Expr) { ret.SourceConnPool = e })
+	walkOption(o.IngestMode, func(e Expr) { ret.IngestMode = e })
+	return ret, anyChanged
+}
+
+// copyNode makes a copy of this Statement without recursing in any child Statements.
+func (n *ShowTenantReplication) copyNode() *ShowTenantReplication {
+	stmtCopy := *n
+	return &stmtCopy
+}
+
+// walkStmt is part of the walkableStmt interface.
+func (n *ShowTenantReplication) walkStmt(v Visitor) Statement {
+	ret := n
+	if n.TenantSpec == nil {
+		return ret
+	}
+	ts, changed := walkTenantSpec(v, n.TenantSpec)
+	if changed {
+		ret = n.copyNode()
+		ret.TenantSpec = ts
 	}
 	return ret
 }
@@ -2050,6 +2133,7 @@ var _ walkableStmt = &SetVar{}
 var _ walkableStmt = &ShowFingerprints{}
 var _ walkableStmt = &ShowTenantClusterSetting{}
 var _ walkableStmt = &ShowTenant{}
+var _ walkableStmt = &ShowTenantReplication{}
 var _ walkableStmt = &UnionClause{}
 var _ walkableStmt = &Update{}
 var _ walkableStmt = &ValuesClause{}
@@ -13,18 +13,27 @@ package upgradecluster
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/cockroach/pkg/util/rangedesc"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 	"google.golang.org/grpc"
@@ -33,6 +42,31 @@ import (
 // Cluster mediates interacting with a cockroach cluster.
 type Cluster struct {
 	c ClusterConfig
+
+	// newMigrationClient constructs the client used to issue migration RPCs
+	// against the given node. It is overridden in tests.
+	newMigrationClient func(roachpb.NodeID, *grpc.ClientConn) serverpb.MigrationClient
+
+	// scanNodeDescriptors reads the descriptors of every node that has recorded
+	// its status in KV. It is overridden in tests.
+	scanNodeDescriptors func(context.Context) ([]roachpb.NodeDescriptor, error)
+
+	mu struct {
+		syncutil.Mutex
+		// executedOn records, for each operation, the nodes it was last
+		// executed on. Operations are typically retried under
+		// UntilClusterStable, and this lets forEveryNode log only what changed
+		// between rounds rather than the full set of nodes every time.
+		executedOn map[string]Nodes
+		// appliedOn records, for each operation, the union of the nodes its
+		// closure succeeded on across all rounds. See AppliedNodes.
+		appliedOn map[string]map[roachpb.NodeID]struct{}
+		// nodeDescs caches the result of NodeDescriptors for the lifetime of
+		// the Cluster, i.e. of the migration it was constructed for. It is
+		// dropped whenever UntilClusterStable observes the membership of the
+		// cluster change.
+		nodeDescs []roachpb.NodeDescriptor
+	}
 }
 
 // ClusterConfig configures a Cluster.
@@ -53,6 +87,20 @@ type ClusterConfig struct {
 	// to expose only relevant, vetted bits of kv.DB. It'll make our tests less
 	// "integration-ey".
 	DB *kv.DB
+
+	// NodeID identifies the local node. It is only needed by operations that
+	// reason about the node they are running on, e.g. IsLocalLeaseholderFor.
+	NodeID *base.SQLIDContainer
+
+	// Executor runs SQL statements against the cluster. It is only needed by
+	// operations that inspect SQL-level state, e.g. NoPendingSchemaChangesFor.
+	Executor isql.Executor
+
+	// NodeListingRetryOptions, if set, is the policy with which listing the
+	// nodes in the cluster is retried when it fails, e.g. because a node is
+	// momentarily unavailable while the cluster is churning. If unset, the
+	// first failure is returned.
+	NodeListingRetryOptions *retry.Options
 }
 
 // NodeDialer abstracts connecting to other nodes in the cluster.
@@ -63,33 +111,147 @@ type NodeDialer interface {
 
 // New constructs a new Cluster with the provided dependencies.
 func New(cfg ClusterConfig) *Cluster {
-	return &Cluster{c: cfg}
+	c := &Cluster{
+		c: cfg,
+		newMigrationClient: func(_ roachpb.NodeID, conn *grpc.ClientConn) serverpb.MigrationClient {
+			return serverpb.NewMigrationClient(conn)
+		},
+	}
+	c.scanNodeDescriptors = c.scanNodeDescriptorsFromKV
+	return c
+}
+
+// nodes returns the live and unavailable nodes in the cluster, sorted by node
+// ID, retrying failures as per the configured NodeListingRetryOptions.
+func (c *Cluster) nodes(ctx context.Context) (live, unavailable Nodes, err error) {
+	if c.c.NodeListingRetryOptions == nil {
+		return NodesFromNodeLiveness(ctx, c.c.NodeLiveness)
+	}
+	for r := retry.StartWithCtx(ctx, *c.c.NodeListingRetryOptions); r.Next(); {
+		live, unavailable, err = NodesFromNodeLiveness(ctx, c.c.NodeLiveness)
+		if err == nil {
+			return live, unavailable, nil
+		}
+		log.Warningf(ctx, "failed to list nodes (attempt %d): %v", r.CurrentAttempt()+1, err)
+	}
+	if err == nil {
+		// The retry loop was never entered.
+		err = ctx.Err()
+	}
+	return nil, nil, errors.Wrap(err, "listing nodes")
 }
 
 // UntilClusterStable is part of the upgrade.Cluster interface.
 func (c *Cluster) UntilClusterStable(
 	ctx context.Context, retryOpts retry.Options, fn func() error,
 ) error {
-	live, unavailable, err := NodesFromNodeLiveness(ctx, c.c.NodeLiveness)
+	_, err := c.untilClusterStable(ctx, retryOpts, fn)
+	return err
+}
+
+// StableNodes returns the set of nodes in the cluster once it is stable, as
+// determined by UntilClusterStable with no work to run in between listings.
+//
+// The returned set can be handed to EveryNodeUsing to run several operations
+// in sequence without re-listing, and re-stabilizing, the nodes in between.
+func (c *Cluster) StableNodes(ctx context.Context, retryOpts retry.Options) (Nodes, error) {
+	return c.untilClusterStable(ctx, retryOpts, func() error { return nil })
+}
+
+// EveryNodeUsing is like ForEveryNodeOrServer, but runs the closure against
+// the given set of nodes, typically obtained through StableNodes, rather than
+// listing the nodes in the cluster.
+//
+// This trades away the guarantee provided by running under
+// UntilClusterStable, i.e. that every node that joins the cluster concurrently
+// is also accounted for, in exchange for not having to list the nodes again.
+// It should only be used where the caller knows the membership of the cluster
+// to be stable, e.g. across several operations in quick succession.
+func (c *Cluster) EveryNodeUsing(
+	ctx context.Context,
+	op string,
+	ns Nodes,
+	fn func(context.Context, serverpb.MigrationClient) error,
+) error {
+	return c.forEveryNode(ctx, op, ns, func(
+		ctx context.Context, _ Node, client serverpb.MigrationClient,
+	) error {
+		return fn(ctx, client)
+	})
+}
+
+// EveryNewNode runs the closure against every node in the cluster that is not
+// in initial, under UntilClusterStable. It is meant for incrementally handling
+// nodes that join after the ones in initial, typically obtained through
+// StableNodes, were already accounted for: each round only runs the closure
+// against the nodes not yet handled, in initial or in an earlier round.
+//
+// The stable set of nodes is returned, and can be handed to a subsequent call
+// as the new initial set.
+func (c *Cluster) EveryNewNode(
+	ctx context.Context,
+	op string,
+	initial Nodes,
+	retryOpts retry.Options,
+	fn func(context.Context, serverpb.MigrationClient) error,
+) (Nodes, error) {
+	handled := make(map[roachpb.NodeID]struct{}, len(initial))
+	for _, n := range initial {
+		handled[n.ID] = struct{}{}
+	}
+	return c.untilClusterStable(ctx, retryOpts, func() error {
+		live, _, err := c.nodes(ctx)
+		if err != nil {
+			return err
+		}
+		var ns Nodes
+		for _, n := range live {
+			if _, ok := handled[n.ID]; !ok {
+				ns = append(ns, n)
+			}
+		}
+		if err := c.forEveryNode(ctx, op, ns, func(
+			ctx context.Context, _ Node, client serverpb.MigrationClient,
+		) error {
+			return fn(ctx, client)
+		}); err != nil {
+			return err
+		}
+		for _, n := range ns {
+			handled[n.ID] = struct{}{}
+		}
+		return nil
+	})
+}
+
+// untilClusterStable implements UntilClusterStable, returning the stable set
+// of live nodes.
+func (c *Cluster) untilClusterStable(
+	ctx context.Context, retryOpts retry.Options, fn func() error,
+) (Nodes, error) {
+	live, unavailable, err := c.nodes(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for r := retry.StartWithCtx(ctx, retryOpts); r.Next(); {
 		for {
 			if err := fn(); err != nil {
-				return err
+				return nil, err
 			}
 
-			curLive, curUnavailable, err := NodesFromNodeLiveness(ctx, c.c.NodeLiveness)
+			curLive, curUnavailable, err := c.nodes(ctx)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			if ok, diffs := live.Identical(curLive); !ok || curUnavailable != nil {
 				log.Infof(ctx, "waiting for cluster stability, unavailable: %v, diff: %v", curUnavailable, diffs)
 				live = curLive
 				unavailable = curUnavailable
+				// The nodes that joined, or rejoined, may have done so with
+				// different descriptors, so the next round looks them up anew.
+				c.invalidateNodeDescriptors()
 
 				if ok {
 					// We want to retry indefinitely when there are no unavailable nodes
@@ -99,18 +261,18 @@ func (c *Cluster) UntilClusterStable(
 					break
 				}
 			} else {
-				return nil
+				return live, nil
 			}
 		}
 	}
 
-	return errors.Newf(
+	return nil, errors.Newf(
 		"cluster not stable, nodes: %v, unavailable: %v", live, unavailable)
 }
 
 // NumNodesOrTenantPods is part of the upgrade.Cluster interface.
 func (c *Cluster) NumNodesOrServers(ctx context.Context) (int, error) {
-	live, unavailable, err := NodesFromNodeLiveness(ctx, c.c.NodeLiveness)
+	live, unavailable, err := c.nodes(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -120,22 +282,424 @@ func (c *Cluster) NumNodesOrServers(ctx context.Context) (int, error) {
 	return len(live), nil
 }
 
+// NodeStores returns the IDs of the stores on each node in the cluster, as
+// recorded in the status records the nodes persist in KV. As with the other
+// operations over every node, decommissioned nodes are excluded. An error is
+// returned if a node has yet to record any of its stores.
+func (c *Cluster) NodeStores(ctx context.Context) (map[roachpb.NodeID][]roachpb.StoreID, error) {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stores := make(map[roachpb.NodeID][]roachpb.StoreID, len(live))
+	for _, node := range live {
+		stores[node.ID] = nil
+	}
+
+	statuses, err := c.scanNodeStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		ids, ok := stores[status.Desc.NodeID]
+		if !ok {
+			// The node has been decommissioned.
+			continue
+		}
+		for _, store := range status.StoreStatuses {
+			ids = append(ids, store.Desc.StoreID)
+		}
+		stores[status.Desc.NodeID] = ids
+	}
+
+	for _, node := range live {
+		ids := stores[node.ID]
+		if len(ids) == 0 {
+			return nil, errors.Newf("no stores recorded for n%d", node.ID)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+	return stores, nil
+}
+
+// NodeDescriptors returns the descriptors of the nodes in the cluster, ordered
+// by node ID. As with the other operations over every node, decommissioned
+// nodes are excluded. An error is returned if a node has yet to record its
+// descriptor.
+//
+// The descriptors are fetched once and cached for the lifetime of the Cluster,
+// so that the several steps of a migration don't each have to look them up.
+// Nodes joining the cluster in the meantime are not reflected in the result,
+// except under UntilClusterStable, which drops the cached descriptors between
+// rounds whenever the membership of the cluster changes.
+func (c *Cluster) NodeDescriptors(ctx context.Context) ([]roachpb.NodeDescriptor, error) {
+	c.mu.Lock()
+	cached := c.mu.nodeDescs
+	c.mu.Unlock()
+	if cached != nil {
+		return append([]roachpb.NodeDescriptor(nil), cached...), nil
+	}
+
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all, err := c.scanNodeDescriptors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[roachpb.NodeID]roachpb.NodeDescriptor, len(all))
+	for _, desc := range all {
+		byID[desc.NodeID] = desc
+	}
+	descs := make([]roachpb.NodeDescriptor, 0, len(live))
+	for _, node := range live {
+		desc, ok := byID[node.ID]
+		if !ok {
+			return nil, errors.Newf("no descriptor recorded for n%d", node.ID)
+		}
+		descs = append(descs, desc)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.nodeDescs = descs
+	return append([]roachpb.NodeDescriptor(nil), descs...), nil
+}
+
+// invalidateNodeDescriptors drops the descriptors cached by NodeDescriptors, so
+// that they are fetched anew the next time they are needed.
+func (c *Cluster) invalidateNodeDescriptors() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.nodeDescs = nil
+}
+
+// scanNodeDescriptorsFromKV reads the node descriptors out of the status
+// records the nodes persist in KV.
+func (c *Cluster) scanNodeDescriptorsFromKV(
+	ctx context.Context,
+) ([]roachpb.NodeDescriptor, error) {
+	statuses, err := c.scanNodeStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	descs := make([]roachpb.NodeDescriptor, 0, len(statuses))
+	for _, status := range statuses {
+		descs = append(descs, status.Desc)
+	}
+	return descs, nil
+}
+
+// scanNodeStatuses reads the status records the nodes persist in KV.
+func (c *Cluster) scanNodeStatuses(ctx context.Context) ([]statuspb.NodeStatus, error) {
+	rows, err := c.c.DB.Scan(ctx, keys.StatusNodePrefix, keys.StatusNodePrefix.PrefixEnd(), 0 /* maxRows */)
+	if err != nil {
+		return nil, errors.Wrap(err, "scanning node statuses")
+	}
+	statuses := make([]statuspb.NodeStatus, len(rows))
+	for i, row := range rows {
+		if err := row.ValueProto(&statuses[i]); err != nil {
+			return nil, errors.Wrapf(err, "decoding node status at %s", row.Key)
+		}
+	}
+	return statuses, nil
+}
+
+// IsLocalLeaseholderFor returns whether the local node holds the lease for the
+// range containing the given key. False is returned if the range has no lease,
+// e.g. because it expired and has yet to be re-acquired.
+//
+// The lease may change hands at any time, so the result is only a hint that
+// callers can use to check locality assumptions, not a guarantee.
+func (c *Cluster) IsLocalLeaseholderFor(ctx context.Context, key roachpb.Key) (bool, error) {
+	if c.c.NodeID == nil {
+		return false, errors.AssertionFailedf("local node ID not configured")
+	}
+	nodeID, ok := c.c.NodeID.OptionalNodeID()
+	if !ok {
+		return false, errors.AssertionFailedf("local node ID not available")
+	}
+
+	req := &kvpb.LeaseInfoRequest{RequestHeader: kvpb.RequestHeader{Key: key}}
+	resp, pErr := kv.SendWrapped(ctx, c.c.DB.NonTransactionalSender(), req)
+	if pErr != nil {
+		return false, errors.Wrapf(pErr.GoError(), "looking up leaseholder for %s", key)
+	}
+	lease := resp.(*kvpb.LeaseInfoResponse).Lease
+	if lease.Empty() {
+		return false, nil
+	}
+	return lease.Replica.NodeID == nodeID, nil
+}
+
+// WriteBatch constructs a batch, lets fn populate it, and runs it against the
+// cluster's kv.DB. The batch is run within a transaction, so it is applied
+// atomically and retried on retryable errors; fn is invoked again on every
+// retry, and must not retain the batch it is handed.
+func (c *Cluster) WriteBatch(ctx context.Context, fn func(b *kv.Batch) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.c.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		b := txn.NewBatch()
+		if err := fn(b); err != nil {
+			return err
+		}
+		return txn.CommitInBatch(ctx, b)
+	})
+}
+
 // ForEveryNodeOrTenantPod is part of the upgrade.Cluster interface.
 func (c *Cluster) ForEveryNodeOrServer(
 	ctx context.Context, op string, fn func(context.Context, serverpb.MigrationClient) error,
 ) error {
 
-	live, _, err := NodesFromNodeLiveness(ctx, c.c.NodeLiveness)
+	live, _, err := c.nodes(ctx)
 	if err != nil {
 		return err
 	}
 
+	return c.forEveryNode(ctx, op, live, func(
+		ctx context.Context, _ Node, client serverpb.MigrationClient,
+	) error {
+		return fn(ctx, client)
+	})
+}
+
+// ForEveryNodeOrServerSkipIf is like ForEveryNodeOrServer, but first probes each
+// node with skipIf, and skips running the closure against the nodes for which
+// it returns true. It is intended for idempotent operations, for which
+// re-running against nodes that already applied them (e.g. when retried under
+// UntilClusterStable) would be wasted work. Errors returned by the probe are
+// annotated as such, to distinguish them from errors returned by the closure.
+func (c *Cluster) ForEveryNodeOrServerSkipIf(
+	ctx context.Context,
+	op string,
+	skipIf func(context.Context, serverpb.MigrationClient) (bool, error),
+	fn func(context.Context, serverpb.MigrationClient) error,
+) error {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.forEveryNode(ctx, op, live, func(
+		ctx context.Context, node Node, client serverpb.MigrationClient,
+	) error {
+		skip, err := skipIf(ctx, client)
+		if err != nil {
+			return errors.Wrapf(err, "probing whether %s was already applied on n%d",
+				redact.Safe(op), node.ID)
+		}
+		if skip {
+			log.VEventf(ctx, 2, "skipping %s on n%d, which already applied it", redact.Safe(op), node.ID)
+			return nil
+		}
+		return fn(ctx, client)
+	})
+}
+
+// EveryNodeTolerant is like ForEveryNodeOrServer, but is intended for
+// best-effort operations: it succeeds as long as the closure fails on no more
+// than maxFailures nodes. Failures within that budget are logged, and an error
+// combining all the failures is returned only once it is exceeded.
+//
+// Note that this relaxes the guarantee usually provided when running under
+// UntilClusterStable: a successful run only guarantees that the closure
+// succeeded on all but (up to) maxFailures of the nodes in the stable set.
+func (c *Cluster) EveryNodeTolerant(
+	ctx context.Context,
+	op string,
+	maxFailures int,
+	fn func(context.Context, serverpb.MigrationClient) error,
+) error {
+	if maxFailures < 0 {
+		return errors.AssertionFailedf("invalid number of tolerated failures: %d", maxFailures)
+	}
+
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.forEveryNodeTolerant(ctx, op, live, maxFailures, func(
+		ctx context.Context, _ Node, client serverpb.MigrationClient,
+	) error {
+		return fn(ctx, client)
+	})
+}
+
+// EveryNodeTimed is like ForEveryNodeOrServer, but also records how long the
+// closure took to run against each node, so that slow nodes holding up an
+// operation can be identified. The timings are returned keyed by node ID, and
+// cover every node the closure completed on, even if it failed on another.
+func (c *Cluster) EveryNodeTimed(
+	ctx context.Context, op string, fn func(context.Context, serverpb.MigrationClient) error,
+) (map[roachpb.NodeID]time.Duration, error) {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu syncutil.Mutex
+	timings := make(map[roachpb.NodeID]time.Duration, len(live))
+	err = c.forEveryNode(ctx, op, live, func(
+		ctx context.Context, node Node, client serverpb.MigrationClient,
+	) error {
+		start := timeutil.Now()
+		err := fn(ctx, client)
+		took := timeutil.Since(start)
+		log.VEventf(ctx, 2, "%s took %s on n%d", redact.Safe(op), took, node.ID)
+
+		mu.Lock()
+		defer mu.Unlock()
+		timings[node.ID] = took
+		return err
+	})
+	return timings, err
+}
+
+// EveryNodeByLocality is like ForEveryNodeOrServer, but groups the nodes by
+// their value for the given locality tier, e.g. "region", and runs the closure
+// against one group at a time, limiting how much of the cluster an operation
+// affects at once. Groups are run in the order of their tier values, with
+// nodes that lack the tier forming a group of their own that runs first. The
+// closure is run concurrently within a group, and every node in a group must
+// succeed before moving on to the next.
+func (c *Cluster) EveryNodeByLocality(
+	ctx context.Context,
+	op string,
+	tier string,
+	fn func(context.Context, serverpb.MigrationClient) error,
+) error {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return err
+	}
+	descs, err := c.NodeDescriptors(ctx)
+	if err != nil {
+		return err
+	}
+	localities := make(map[roachpb.NodeID]string, len(descs))
+	for _, desc := range descs {
+		value, _ := desc.Locality.Find(tier)
+		localities[desc.NodeID] = value
+	}
+
+	groups := make(map[string]Nodes)
+	for _, node := range live {
+		value, ok := localities[node.ID]
+		if !ok {
+			return errors.Newf("no descriptor recorded for n%d", node.ID)
+		}
+		groups[value] = append(groups[value], node)
+	}
+	values := make([]string, 0, len(groups))
+	for value := range groups {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	for _, value := range values {
+		groupOp := fmt.Sprintf("%s (%s=%s)", op, tier, value)
+		if err := c.forEveryNode(ctx, groupOp, groups[value], func(
+			ctx context.Context, _ Node, client serverpb.MigrationClient,
+		) error {
+			return fn(ctx, client)
+		}); err != nil {
+			return errors.Wrapf(err, "running %s on nodes with %s=%s",
+				redact.Safe(op), redact.Safe(tier), value)
+		}
+	}
+	return nil
+}
+
+// EveryNodeByLocalityUntilStable runs EveryNodeByLocality under
+// UntilClusterStable. Each time the membership of the cluster changes, the
+// node descriptors are refreshed before the next round, so that the nodes are
+// grouped by their current localities rather than those cached when the
+// descriptors were first fetched.
+func (c *Cluster) EveryNodeByLocalityUntilStable(
+	ctx context.Context,
+	op string,
+	tier string,
+	retryOpts retry.Options,
+	fn func(context.Context, serverpb.MigrationClient) error,
+) error {
+	return c.UntilClusterStable(ctx, retryOpts, func() error {
+		return c.EveryNodeByLocality(ctx, op, tier, fn)
+	})
+}
+
+// OnFirstResponsiveNode runs the closure against the nodes in the cluster one
+// at a time, in node ID order, stopping at the first node it succeeds on. It is
+// meant for read probes of cluster-wide consistent values, which any single
+// node can serve. Nodes that cannot be dialed, or on which the closure fails,
+// are skipped; an error combining every failure is returned only if the
+// closure did not succeed on any node.
+func (c *Cluster) OnFirstResponsiveNode(
+	ctx context.Context, op string, fn func(context.Context, serverpb.MigrationClient) error,
+) error {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return err
+	}
+	if len(live) == 0 {
+		return errors.Newf("no nodes to run %s on", redact.Safe(op))
+	}
+
+	var combined error
+	for _, node := range live {
+		err := func() error {
+			conn, err := c.c.Dialer.Dial(ctx, node.ID, rpc.DefaultClass)
+			if err != nil {
+				return err
+			}
+			return fn(ctx, c.newMigrationClient(node.ID, conn))
+		}()
+		if err == nil {
+			c.recordAppliedOn(op, node.ID)
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.CombineErrors(ctxErr, combined)
+		}
+		log.VEventf(ctx, 2, "%s failed on n%d, trying the next node: %v", redact.Safe(op), node.ID, err)
+		combined = errors.CombineErrors(combined, errors.Wrapf(err, "n%d", node.ID))
+	}
+	return errors.Wrapf(combined, "%s failed on all %d nodes", redact.Safe(op), len(live))
+}
+
+// forEveryNode executes the given closure against every node in ns,
+// concurrently. The closure is handed the node it is being run against.
+func (c *Cluster) forEveryNode(
+	ctx context.Context,
+	op string,
+	ns Nodes,
+	fn func(context.Context, Node, serverpb.MigrationClient) error,
+) error {
+	return c.forEveryNodeTolerant(ctx, op, ns, 0 /* maxFailures */, fn)
+}
+
+// forEveryNodeTolerant is like forEveryNode, but only returns an error once
+// executing the closure (or dialing the node) has failed on more than
+// maxFailures nodes.
+func (c *Cluster) forEveryNodeTolerant(
+	ctx context.Context,
+	op string,
+	ns Nodes,
+	maxFailures int,
+	fn func(context.Context, Node, serverpb.MigrationClient) error,
+) error {
 	// We'll want to rate limit outgoing RPCs (limit pulled out of thin air).
 	qp := quotapool.NewIntPool("every-node", 25)
-	log.Infof(ctx, "executing %s on nodes %s", redact.Safe(op), live)
+	c.logExecution(ctx, op, ns)
 	grp := ctxgroup.WithContext(ctx)
 
-	for _, node := range live {
+	var mu syncutil.Mutex
+	var failures []error
+	for _, node := range ns {
 		alloc, err := qp.Acquire(ctx, 1)
 		if err != nil {
 			return err
@@ -144,17 +708,109 @@ func (c *Cluster) ForEveryNodeOrServer(
 		grp.GoCtx(func(ctx context.Context) error {
 			defer alloc.Release()
 
-			conn, err := c.c.Dialer.Dial(ctx, node.ID, rpc.DefaultClass)
-			if err != nil {
+			err := func() error {
+				conn, err := c.c.Dialer.Dial(ctx, node.ID, rpc.DefaultClass)
+				if err != nil {
+					return err
+				}
+				client := c.newMigrationClient(node.ID, conn)
+				return fn(ctx, node, client)
+			}()
+			if err == nil {
+				c.recordAppliedOn(op, node.ID)
+			}
+			if err == nil || maxFailures == 0 {
 				return err
 			}
-			client := serverpb.NewMigrationClient(conn)
-			return fn(ctx, client)
+
+			mu.Lock()
+			defer mu.Unlock()
+			failures = append(failures, errors.Wrapf(err, "n%d", node.ID))
+			if len(failures) > maxFailures {
+				var combined error
+				for _, failure := range failures {
+					combined = errors.CombineErrors(combined, failure)
+				}
+				return errors.Wrapf(combined, "%s failed on more than %d nodes", redact.Safe(op), maxFailures)
+			}
+			log.Warningf(ctx, "%s failed on n%d, tolerating failure %d of %d: %v",
+				redact.Safe(op), node.ID, len(failures), maxFailures, err)
+			return nil
 		})
 	}
 	return grp.Wait()
 }
 
+// recordAppliedOn records that op's closure succeeded on the given node.
+func (c *Cluster) recordAppliedOn(op string, id roachpb.NodeID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.appliedOn == nil {
+		c.mu.appliedOn = make(map[string]map[roachpb.NodeID]struct{})
+	}
+	if c.mu.appliedOn[op] == nil {
+		c.mu.appliedOn[op] = make(map[roachpb.NodeID]struct{})
+	}
+	c.mu.appliedOn[op][id] = struct{}{}
+}
+
+// AppliedNodes returns, in node ID order, every node the closure of the given
+// operation was successfully run against over the lifetime of the Cluster.
+// When the operation is run under UntilClusterStable, this is the union of the
+// nodes across all rounds, including nodes that have since left the cluster,
+// providing an auditable record of where it was applied.
+func (c *Cluster) AppliedNodes(op string) []roachpb.NodeID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]roachpb.NodeID, 0, len(c.mu.appliedOn[op]))
+	for id := range c.mu.appliedOn[op] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// CheckNodeHealth dials the given node and sends it a lightweight migration
+// RPC, returning an error naming the node if it cannot be reached or fails to
+// respond. Upgrades can use it to validate specific nodes before running a
+// targeted operation against them.
+func (c *Cluster) CheckNodeHealth(ctx context.Context, id roachpb.NodeID) error {
+	conn, err := c.c.Dialer.Dial(ctx, id, rpc.DefaultClass)
+	if err != nil {
+		return errors.Wrapf(err, "n%d is unreachable", id)
+	}
+	client := c.newMigrationClient(id, conn)
+	if _, err := client.GetClusterVersion(ctx, &serverpb.GetClusterVersionRequest{}); err != nil {
+		return errors.Wrapf(err, "n%d is unhealthy", id)
+	}
+	return nil
+}
+
+// logExecution logs that op is about to be executed on ns. The first round of
+// an operation logs the full set of nodes; subsequent rounds only log the
+// nodes that joined, left or restarted since the previous round, if any.
+func (c *Cluster) logExecution(ctx context.Context, op string, ns Nodes) {
+	c.mu.Lock()
+	prev, retried := c.mu.executedOn[op]
+	if c.mu.executedOn == nil {
+		c.mu.executedOn = make(map[string]Nodes)
+	}
+	c.mu.executedOn[op] = ns
+	c.mu.Unlock()
+
+	if !retried {
+		log.Infof(ctx, "executing %s on nodes %s", redact.Safe(op), ns)
+		return
+	}
+	ok, diffs := prev.Identical(ns)
+	if ok {
+		log.VEventf(ctx, 2, "re-executing %s on unchanged nodes", redact.Safe(op))
+		return
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i] < diffs[j] })
+	log.Infof(ctx, "re-executing %s, nodes changed: %v", redact.Safe(op), diffs)
+}
+
 // IterateRangeDescriptors is part of the upgrade.Cluster interface.
 func (c *Cluster) IterateRangeDescriptors(
 	ctx context.Context, blockSize int, init func(), fn func(...roachpb.RangeDescriptor) error,
@@ -0,0 +1,245 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgradecluster_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/upgrade/upgradecluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterNodeStores(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const numNodes, storesPerNode = 3, 2
+
+	storeSpecs := make([]base.StoreSpec, storesPerNode)
+	for i := range storeSpecs {
+		storeSpecs[i] = base.DefaultTestStoreSpec
+	}
+	tc := testcluster.StartTestCluster(t, numNodes, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+		ServerArgs: base.TestServerArgs{
+			StoreSpecs: storeSpecs,
+		},
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	s := tc.Server(0)
+	c := upgradecluster.New(upgradecluster.ClusterConfig{
+		NodeLiveness: s.NodeLiveness().(livenesspb.NodeVitalityInterface),
+		DB:           s.DB(),
+	})
+
+	testutils.SucceedsSoon(t, func() error {
+		stores, err := c.NodeStores(ctx)
+		if err != nil {
+			return err
+		}
+		if len(stores) != numNodes {
+			return errors.Newf("expected stores for %d nodes, got %v", numNodes, stores)
+		}
+		seen := make(map[roachpb.StoreID]struct{})
+		for i := 0; i < numNodes; i++ {
+			nodeID := tc.Server(i).NodeID()
+			ids := stores[nodeID]
+			if len(ids) != storesPerNode {
+				return errors.Newf("expected %d stores on n%d, got %v", storesPerNode, nodeID, ids)
+			}
+			for _, id := range ids {
+				if _, ok := seen[id]; ok {
+					return errors.Newf("store s%d reported on more than one node: %v", id, stores)
+				}
+				seen[id] = struct{}{}
+			}
+		}
+		return nil
+	})
+}
+
+func TestClusterWriteBatch(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s := serverutils.StartServerOnly(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestIsSpecificToStorageLayerAndNeedsASystemTenant,
+	})
+	defer s.Stopper().Stop(ctx)
+
+	c := upgradecluster.New(upgradecluster.ClusterConfig{
+		NodeLiveness: s.NodeLiveness().(livenesspb.NodeVitalityInterface),
+		DB:           s.DB(),
+	})
+
+	const numKeys = 5
+	key := func(i int) roachpb.Key {
+		return append(keys.ScratchRangeMin.Clone(), byte('a'+i))
+	}
+	require.NoError(t, c.WriteBatch(ctx, func(b *kv.Batch) error {
+		for i := 0; i < numKeys; i++ {
+			b.Put(key(i), i)
+		}
+		return nil
+	}))
+	rows, err := s.DB().Scan(ctx, key(0), key(numKeys), 0 /* maxRows */)
+	require.NoError(t, err)
+	require.Len(t, rows, numKeys)
+	for i, row := range rows {
+		require.Equal(t, key(i), row.Key)
+		v, err := row.Value.GetInt()
+		require.NoError(t, err)
+		require.Equal(t, int64(i), v)
+	}
+
+	// Errors returned by the closure are propagated, and nothing is written.
+	require.ErrorContains(t, c.WriteBatch(ctx, func(b *kv.Batch) error {
+		b.Put(key(numKeys), numKeys)
+		return errors.New("boom")
+	}), "boom")
+	kvs, err := s.DB().Scan(ctx, key(numKeys), key(numKeys+1), 0 /* maxRows */)
+	require.NoError(t, err)
+	require.Empty(t, kvs)
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(t, c.WriteBatch(cancelledCtx, func(b *kv.Batch) error {
+		t.Fatal("unexpected call to the closure with a cancelled context")
+		return nil
+	}), context.Canceled)
+}
+
+func TestClusterIsLocalLeaseholderFor(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 2, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	s := tc.Server(0)
+	var nodeID base.NodeIDContainer
+	nodeID.Set(ctx, s.NodeID())
+	c := upgradecluster.New(upgradecluster.ClusterConfig{
+		NodeLiveness: s.NodeLiveness().(livenesspb.NodeVitalityInterface),
+		DB:           s.DB(),
+		NodeID:       base.NewSQLIDContainerForNode(&nodeID),
+	})
+
+	key := tc.ScratchRange(t)
+	desc := tc.AddVotersOrFatal(t, key, tc.Target(1))
+
+	tc.TransferRangeLeaseOrFatal(t, desc, tc.Target(0))
+	testutils.SucceedsSoon(t, func() error {
+		local, err := c.IsLocalLeaseholderFor(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !local {
+			return errors.New("expected the local node to hold the lease")
+		}
+		return nil
+	})
+
+	tc.TransferRangeLeaseOrFatal(t, desc, tc.Target(1))
+	testutils.SucceedsSoon(t, func() error {
+		local, err := c.IsLocalLeaseholderFor(ctx, key)
+		if err != nil {
+			return err
+		}
+		if local {
+			return errors.New("expected a remote node to hold the lease")
+		}
+		return nil
+	})
+
+	// Without the local node ID, the leaseholder cannot be compared against it.
+	noNodeID := upgradecluster.New(upgradecluster.ClusterConfig{DB: s.DB()})
+	_, err := noNodeID.IsLocalLeaseholderFor(ctx, key)
+	require.ErrorContains(t, err, "local node ID not configured")
+}
+
+func TestClusterNoPendingSchemaChangesFor(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestIsSpecificToStorageLayerAndNeedsASystemTenant,
+		Knobs: base.TestingKnobs{
+			JobsTestingKnobs: jobs.NewTestingKnobsWithShortIntervals(),
+		},
+	})
+	defer s.Stopper().Stop(ctx)
+	tdb := sqlutils.MakeSQLRunner(sqlDB)
+	c := upgradecluster.New(upgradecluster.ClusterConfig{
+		DB:       s.DB(),
+		Executor: s.InternalDB().(isql.DB).Executor(),
+	})
+
+	tdb.Exec(t, `SET CLUSTER SETTING sql.defaults.use_declarative_schema_changer = 'off'`)
+	tdb.Exec(t, `CREATE TABLE watched (i INT PRIMARY KEY)`)
+	tdb.Exec(t, `CREATE TABLE other (i INT PRIMARY KEY)`)
+	var watchedID, otherID descpb.ID
+	tdb.QueryRow(t, `SELECT 'watched'::REGCLASS::INT`).Scan(&watchedID)
+	tdb.QueryRow(t, `SELECT 'other'::REGCLASS::INT`).Scan(&otherID)
+
+	noPending, err := c.NoPendingSchemaChangesFor(ctx, watchedID, otherID)
+	require.NoError(t, err)
+	require.True(t, noPending)
+
+	// Leave a schema change on the watched table paused, and hence pending.
+	tdb.Exec(t, `SET CLUSTER SETTING jobs.debug.pausepoints = 'schemachanger.before.exec'`)
+	_, err = sqlDB.Exec(`CREATE INDEX idx ON watched (i)`)
+	jobID := regexp.MustCompile(`\d+`).FindString(err.Error())
+	require.NotEmpty(t, jobID)
+
+	noPending, err = c.NoPendingSchemaChangesFor(ctx, watchedID)
+	require.False(t, noPending)
+	require.ErrorContains(t, err, jobID)
+
+	// Schema changes on other tables do not block.
+	noPending, err = c.NoPendingSchemaChangesFor(ctx, otherID)
+	require.NoError(t, err)
+	require.True(t, noPending)
+
+	tdb.Exec(t, `SET CLUSTER SETTING jobs.debug.pausepoints = ''`)
+	tdb.Exec(t, `RESUME JOB $1`, jobID)
+	testutils.SucceedsSoon(t, func() error {
+		noPending, err := c.NoPendingSchemaChangesFor(ctx, watchedID)
+		if !noPending {
+			return errors.Wrap(err, "schema change still pending")
+		}
+		return err
+	})
+}
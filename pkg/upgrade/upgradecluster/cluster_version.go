@@ -0,0 +1,148 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgradecluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// waitForVersionRetryOptions bounds how long WaitForVersionOnAllNodes polls
+// for lagging nodes before giving up.
+var waitForVersionRetryOptions = retry.Options{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	Multiplier:     2,
+	MaxRetries:     20,
+}
+
+// WaitForVersionOnAllNodes blocks until every node in the cluster reports an
+// active cluster version of at least v. Nodes are polled with bounded retries;
+// if some nodes still lag behind once the retries are exhausted, the returned
+// error names them along with the version they last reported.
+func (c *Cluster) WaitForVersionOnAllNodes(
+	ctx context.Context, v clusterversion.ClusterVersion,
+) error {
+	var lagging map[roachpb.NodeID]clusterversion.ClusterVersion
+	for r := retry.StartWithCtx(ctx, waitForVersionRetryOptions); r.Next(); {
+		live, _, err := c.nodes(ctx)
+		if err != nil {
+			return err
+		}
+
+		var mu syncutil.Mutex
+		lagging = make(map[roachpb.NodeID]clusterversion.ClusterVersion)
+		if err := c.forEveryNode(ctx, "get-cluster-version", live, func(
+			ctx context.Context, node Node, client serverpb.MigrationClient,
+		) error {
+			resp, err := client.GetClusterVersion(ctx, &serverpb.GetClusterVersionRequest{})
+			if err != nil {
+				return err
+			}
+			if resp.ClusterVersion.Less(v.Version) {
+				mu.Lock()
+				defer mu.Unlock()
+				lagging[node.ID] = *resp.ClusterVersion
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		if len(lagging) == 0 {
+			return nil
+		}
+		log.Infof(ctx, "waiting for %d node(s) to observe cluster version %s", len(lagging), v)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.Newf("nodes have not observed cluster version %s: %s",
+		v, formatLaggingNodes(lagging))
+}
+
+// BumpClusterVersion sends the BumpClusterVersion RPC with v to every node in
+// the cluster, and then confirms that every node reports v as its active
+// cluster version. If some nodes do not, the returned error names them along
+// with the version they reported instead.
+//
+// Unlike WaitForVersionOnAllNodes, the confirmation is not retried: a node
+// that acknowledged the bump is expected to have adopted it already.
+func (c *Cluster) BumpClusterVersion(ctx context.Context, v clusterversion.ClusterVersion) error {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &serverpb.BumpClusterVersionRequest{ClusterVersion: &v}
+	op := fmt.Sprintf("bump-cluster-version=%s", v.PrettyPrint())
+	if err := c.forEveryNode(ctx, op, live, func(
+		ctx context.Context, _ Node, client serverpb.MigrationClient,
+	) error {
+		_, err := client.BumpClusterVersion(ctx, req)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	var mu syncutil.Mutex
+	lagging := make(map[roachpb.NodeID]clusterversion.ClusterVersion)
+	if err := c.forEveryNode(ctx, "confirm-"+op, live, func(
+		ctx context.Context, node Node, client serverpb.MigrationClient,
+	) error {
+		resp, err := client.GetClusterVersion(ctx, &serverpb.GetClusterVersionRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.ClusterVersion.Less(v.Version) {
+			mu.Lock()
+			defer mu.Unlock()
+			lagging[node.ID] = *resp.ClusterVersion
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(lagging) > 0 {
+		return errors.Newf("nodes did not adopt cluster version %s: %s",
+			v, formatLaggingNodes(lagging))
+	}
+	return nil
+}
+
+// formatLaggingNodes renders the given nodes, along with the cluster version
+// each reported, in node ID order.
+func formatLaggingNodes(lagging map[roachpb.NodeID]clusterversion.ClusterVersion) string {
+	ids := make([]roachpb.NodeID, 0, len(lagging))
+	for id := range lagging {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var buf strings.Builder
+	for i, id := range ids {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "n%d at %s", id, lagging[id])
+	}
+	return buf.String()
+}
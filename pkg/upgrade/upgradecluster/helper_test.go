@@ -12,17 +12,27 @@ package upgradecluster
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
 	"google.golang.org/grpc"
 )
 
@@ -36,6 +46,96 @@ func (n NoopDialer) Dial(
 
 var _ NodeDialer = NoopDialer{}
 
+// failingDialer is a NodeDialer that fails to dial the given node, and
+// otherwise behaves like NoopDialer.
+type failingDialer struct {
+	unreachable roachpb.NodeID
+}
+
+func (f failingDialer) Dial(
+	ctx context.Context, id roachpb.NodeID, class rpc.ConnectionClass,
+) (*grpc.ClientConn, error) {
+	if id == f.unreachable {
+		return nil, errors.New("injected dial failure")
+	}
+	return nil, nil
+}
+
+var _ NodeDialer = failingDialer{}
+
+// fakeMigrationClient is a serverpb.MigrationClient for a single node whose
+// RPCs are served by the hooks below. Unset hooks panic when invoked.
+type fakeMigrationClient struct {
+	serverpb.MigrationClient
+
+	nodeID             roachpb.NodeID
+	getClusterVersion  func(roachpb.NodeID) (clusterversion.ClusterVersion, error)
+	bumpClusterVersion func(roachpb.NodeID, clusterversion.ClusterVersion) error
+	getSettingValue    func(roachpb.NodeID, string) (string, error)
+}
+
+var _ serverpb.MigrationClient = &fakeMigrationClient{}
+
+func (f *fakeMigrationClient) GetClusterVersion(
+	ctx context.Context, _ *serverpb.GetClusterVersionRequest, _ ...grpc.CallOption,
+) (*serverpb.GetClusterVersionResponse, error) {
+	cv, err := f.getClusterVersion(f.nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &serverpb.GetClusterVersionResponse{ClusterVersion: &cv}, nil
+}
+
+func (f *fakeMigrationClient) BumpClusterVersion(
+	ctx context.Context, req *serverpb.BumpClusterVersionRequest, _ ...grpc.CallOption,
+) (*serverpb.BumpClusterVersionResponse, error) {
+	if err := f.bumpClusterVersion(f.nodeID, *req.ClusterVersion); err != nil {
+		return nil, err
+	}
+	return &serverpb.BumpClusterVersionResponse{}, nil
+}
+
+func (f *fakeMigrationClient) GetSettingValue(
+	ctx context.Context, req *serverpb.GetSettingValueRequest, _ ...grpc.CallOption,
+) (*serverpb.GetSettingValueResponse, error) {
+	value, err := f.getSettingValue(f.nodeID, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &serverpb.GetSettingValueResponse{Value: value}, nil
+}
+
+// flakyNodeVitality wraps a NodeVitalityInterface, failing the first
+// failures scans of node liveness records.
+type flakyNodeVitality struct {
+	livenesspb.NodeVitalityInterface
+
+	mu       syncutil.Mutex
+	failures int
+}
+
+func (f *flakyNodeVitality) ScanNodeVitalityFromKV(
+	ctx context.Context,
+) (livenesspb.NodeVitalityMap, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("injected node liveness scan failure")
+	}
+	return f.NodeVitalityInterface.ScanNodeVitalityFromKV(ctx)
+}
+
+// withFakeMigrationClients makes the given cluster issue its migration RPCs
+// against copies of the provided fake client.
+func withFakeMigrationClients(h *Cluster, fake fakeMigrationClient) {
+	h.newMigrationClient = func(id roachpb.NodeID, _ *grpc.ClientConn) serverpb.MigrationClient {
+		client := fake
+		client.nodeID = id
+		return &client
+	}
+}
+
 func TestHelperEveryNode(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -82,6 +182,50 @@ func TestHelperEveryNode(t *testing.T) {
 		}
 	})
 
+	t.Run("applied-nodes-across-rounds", func(t *testing.T) {
+		// Add a node and decommission another mid-way through execution. The
+		// nodes the closure was applied to should include every node that was
+		// a member of the cluster during any of the rounds.
+		tc := livenesspb.TestCreateNodeVitality(1, 2, 3)
+		h := New(ClusterConfig{
+			NodeLiveness: tc,
+			Dialer:       NoopDialer{},
+		})
+		opCount := 0
+		err := h.UntilClusterStable(ctx, retry.Options{
+			// Speed up testing, run for at most 10 retries over a second.
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     100 * time.Millisecond,
+			Multiplier:     1.0,
+			MaxRetries:     10,
+		}, func() error {
+			return h.ForEveryNodeOrServer(ctx, "audited-op", func(
+				context.Context, serverpb.MigrationClient,
+			) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				opCount++
+				if opCount == numNodes {
+					tc.AddNextNode()
+					tc.Decommissioned(1, false /* alive */)
+				}
+
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if exp, got := []roachpb.NodeID{1, 2, 3, 4}, h.AppliedNodes("audited-op"); !reflect.DeepEqual(exp, got) {
+			t.Fatalf("expected closure to be applied to %v, got %v", exp, got)
+		}
+		if got := h.AppliedNodes("other-op"); len(got) != 0 {
+			t.Fatalf("expected no nodes for an operation that never ran, got %v", got)
+		}
+	})
+
 	t.Run("with-node-restart", func(t *testing.T) {
 		// Restart a node mid-way through execution. We expect EveryNode to
 		// start over from scratch and include the restarted node.
@@ -231,6 +375,35 @@ func TestClusterNodes(t *testing.T) {
 		}
 	})
 
+	t.Run("sorted", func(t *testing.T) {
+		// The liveness records are returned in map order, which differs from
+		// one scan to the next; the nodes are always listed by node ID.
+		nl := livenesspb.TestCreateNodeVitality(7, 3, 9, 1, 5, 2, 8, 4, 6)
+		nl.DownNode(8)
+		nl.DownNode(2)
+		h := New(ClusterConfig{NodeLiveness: nl})
+		for i := 0; i < 10; i++ {
+			live, unavailable, err := h.nodes(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []roachpb.NodeID
+			for _, n := range live {
+				ids = append(ids, n.ID)
+			}
+			if exp := []roachpb.NodeID{1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(ids, exp) {
+				t.Fatalf("expected live nodes %v, got %v", exp, ids)
+			}
+			ids = ids[:0]
+			for _, n := range unavailable {
+				ids = append(ids, n.ID)
+			}
+			if exp := []roachpb.NodeID{2, 8}; !reflect.DeepEqual(ids, exp) {
+				t.Fatalf("expected unavailable nodes %v, got %v", exp, ids)
+			}
+		}
+	})
+
 	t.Run("errors-if-down", func(t *testing.T) {
 		nl := livenesspb.TestCreateNodeVitality(1, 2, 3)
 		const downedNode = 3
@@ -245,3 +418,1054 @@ func TestClusterNodes(t *testing.T) {
 		}
 	})
 }
+
+func TestWaitForVersionOnAllNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	defer func(opts retry.Options) { waitForVersionRetryOptions = opts }(waitForVersionRetryOptions)
+	waitForVersionRetryOptions = retry.Options{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1.0,
+		MaxRetries:     5,
+	}
+
+	oldCV := clusterversion.ClusterVersion{Version: roachpb.Version{Major: 24, Minor: 1}}
+	newCV := clusterversion.ClusterVersion{Version: roachpb.Version{Major: 24, Minor: 2}}
+
+	t.Run("lagging-node-catches-up", func(t *testing.T) {
+		// Node 2 reports the old version for its first two polls, after which it
+		// catches up.
+		const laggingNode = 2
+		h := New(ClusterConfig{
+			NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+			Dialer:       NoopDialer{},
+		})
+		var mu syncutil.Mutex
+		polls := make(map[roachpb.NodeID]int)
+		withFakeMigrationClients(h, fakeMigrationClient{
+			getClusterVersion: func(id roachpb.NodeID) (clusterversion.ClusterVersion, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				polls[id]++
+				if id == laggingNode && polls[id] <= 2 {
+					return oldCV, nil
+				}
+				return newCV, nil
+			},
+		})
+
+		if err := h.WaitForVersionOnAllNodes(ctx, newCV); err != nil {
+			t.Fatal(err)
+		}
+		if exp := 3; polls[laggingNode] != exp {
+			t.Fatalf("expected lagging node to be polled %d times, got %d", exp, polls[laggingNode])
+		}
+	})
+
+	t.Run("lagging-node-never-catches-up", func(t *testing.T) {
+		const laggingNode = 3
+		h := New(ClusterConfig{
+			NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+			Dialer:       NoopDialer{},
+		})
+		withFakeMigrationClients(h, fakeMigrationClient{
+			getClusterVersion: func(id roachpb.NodeID) (clusterversion.ClusterVersion, error) {
+				if id == laggingNode {
+					return oldCV, nil
+				}
+				return newCV, nil
+			},
+		})
+
+		expRe := "nodes have not observed cluster version 24.2: n3 at 24.1"
+		if err := h.WaitForVersionOnAllNodes(ctx, newCV); !testutils.IsError(err, expRe) {
+			t.Fatalf("expected error %q, got %v", expRe, err)
+		}
+	})
+}
+
+func TestSettingConsistentAcrossNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	const key = "kv.rangefeed.enabled"
+	for _, tc := range []struct {
+		name   string
+		values map[roachpb.NodeID]string
+		expRe  string
+	}{
+		{
+			name:   "consistent",
+			values: map[roachpb.NodeID]string{1: "true", 2: "true", 3: "true"},
+		},
+		{
+			name:   "one-divergent-node",
+			values: map[roachpb.NodeID]string{1: "true", 2: "false", 3: "true"},
+			expRe:  `setting kv.rangefeed.enabled is not consistent across nodes: 2 of 3 nodes observe "true", but n2 observes "false"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := New(ClusterConfig{
+				NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+				Dialer:       NoopDialer{},
+			})
+			withFakeMigrationClients(h, fakeMigrationClient{
+				getSettingValue: func(id roachpb.NodeID, k string) (string, error) {
+					if k != key {
+						return "", errors.Newf("unexpected setting %q", k)
+					}
+					return tc.values[id], nil
+				},
+			})
+
+			ok, err := h.SettingConsistentAcrossNodes(ctx, key)
+			if tc.expRe == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !ok {
+					t.Fatal("expected setting to be consistent across nodes")
+				}
+				return
+			}
+			if !testutils.IsError(err, tc.expRe) {
+				t.Fatalf("expected error %q, got %v", tc.expRe, err)
+			}
+			if ok {
+				t.Fatal("expected setting to be reported as inconsistent")
+			}
+		})
+	}
+}
+
+func TestCheckNodeHealth(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	cv := clusterversion.ClusterVersion{Version: roachpb.Version{Major: 24, Minor: 1}}
+	h := New(ClusterConfig{
+		NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+		Dialer:       failingDialer{unreachable: 2},
+	})
+	withFakeMigrationClients(h, fakeMigrationClient{
+		getClusterVersion: func(id roachpb.NodeID) (clusterversion.ClusterVersion, error) {
+			if id == 3 {
+				return clusterversion.ClusterVersion{}, errors.New("injected rpc failure")
+			}
+			return cv, nil
+		},
+	})
+
+	if err := h.CheckNodeHealth(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		id    roachpb.NodeID
+		expRe string
+	}{
+		{id: 2, expRe: "n2 is unreachable: injected dial failure"},
+		{id: 3, expRe: "n3 is unhealthy: injected rpc failure"},
+	} {
+		if err := h.CheckNodeHealth(ctx, tc.id); !testutils.IsError(err, tc.expRe) {
+			t.Fatalf("expected error %q, got %v", tc.expRe, err)
+		}
+	}
+}
+
+func TestOnFirstResponsiveNode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	cv := clusterversion.ClusterVersion{Version: roachpb.Version{Major: 24, Minor: 1}}
+	h := New(ClusterConfig{
+		NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3, 4),
+		Dialer:       failingDialer{unreachable: 1},
+	})
+	withFakeMigrationClients(h, fakeMigrationClient{
+		getClusterVersion: func(id roachpb.NodeID) (clusterversion.ClusterVersion, error) {
+			if id == 2 {
+				return clusterversion.ClusterVersion{}, errors.New("injected rpc failure")
+			}
+			return cv, nil
+		},
+	})
+
+	// n1 cannot be dialed and the probe fails on n2, so it succeeds on n3 and
+	// is never run against n4.
+	var tried []roachpb.NodeID
+	var got clusterversion.ClusterVersion
+	if err := h.OnFirstResponsiveNode(ctx, "probe", func(
+		ctx context.Context, client serverpb.MigrationClient,
+	) error {
+		tried = append(tried, client.(*fakeMigrationClient).nodeID)
+		resp, err := client.GetClusterVersion(ctx, &serverpb.GetClusterVersionRequest{})
+		if err != nil {
+			return err
+		}
+		got = *resp.ClusterVersion
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if exp := []roachpb.NodeID{2, 3}; !reflect.DeepEqual(tried, exp) {
+		t.Fatalf("expected the probe to be run against %v, got %v", exp, tried)
+	}
+	if got != cv {
+		t.Fatalf("expected to read %s, got %s", cv, got)
+	}
+	if exp, applied := []roachpb.NodeID{3}, h.AppliedNodes("probe"); !reflect.DeepEqual(applied, exp) {
+		t.Fatalf("expected the probe to be applied on %v, got %v", exp, applied)
+	}
+
+	// If the closure fails everywhere, every failure is reported.
+	err := h.OnFirstResponsiveNode(ctx, "failing-probe", func(
+		ctx context.Context, client serverpb.MigrationClient,
+	) error {
+		return errors.Newf("injected failure on n%d", client.(*fakeMigrationClient).nodeID)
+	})
+	if !testutils.IsError(err, "failing-probe failed on all 4 nodes: n1: injected dial failure") {
+		t.Fatalf("expected the probe to fail on all nodes, got %v", err)
+	}
+	details := fmt.Sprintf("%+v", err)
+	for _, id := range []roachpb.NodeID{2, 3, 4} {
+		if exp := fmt.Sprintf("n%d: injected failure on n%d", id, id); !strings.Contains(details, exp) {
+			t.Fatalf("expected error to contain %q, got %s", exp, details)
+		}
+	}
+}
+
+func TestBumpClusterVersion(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	oldCV := clusterversion.ClusterVersion{Version: roachpb.Version{Major: 24, Minor: 1}}
+	newCV := clusterversion.ClusterVersion{Version: roachpb.Version{Major: 24, Minor: 2}}
+
+	// fakeCluster returns a three node cluster whose nodes adopt the versions
+	// they are bumped to, except for the ones in stuck.
+	fakeCluster := func(stuck ...roachpb.NodeID) (*Cluster, map[roachpb.NodeID]clusterversion.ClusterVersion) {
+		h := New(ClusterConfig{
+			NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+			Dialer:       NoopDialer{},
+		})
+		var mu syncutil.Mutex
+		versions := map[roachpb.NodeID]clusterversion.ClusterVersion{1: oldCV, 2: oldCV, 3: oldCV}
+		withFakeMigrationClients(h, fakeMigrationClient{
+			bumpClusterVersion: func(id roachpb.NodeID, cv clusterversion.ClusterVersion) error {
+				mu.Lock()
+				defer mu.Unlock()
+				for _, s := range stuck {
+					if s == id {
+						return nil
+					}
+				}
+				versions[id] = cv
+				return nil
+			},
+			getClusterVersion: func(id roachpb.NodeID) (clusterversion.ClusterVersion, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				return versions[id], nil
+			},
+		})
+		return h, versions
+	}
+
+	t.Run("all-nodes-adopt", func(t *testing.T) {
+		h, versions := fakeCluster()
+		if err := h.BumpClusterVersion(ctx, newCV); err != nil {
+			t.Fatal(err)
+		}
+		for id, cv := range versions {
+			if cv != newCV {
+				t.Fatalf("expected n%d at %s, got %s", id, newCV, cv)
+			}
+		}
+	})
+
+	t.Run("node-does-not-adopt", func(t *testing.T) {
+		h, _ := fakeCluster(2)
+		expRe := "nodes did not adopt cluster version 24.2: n2 at 24.1"
+		if err := h.BumpClusterVersion(ctx, newCV); !testutils.IsError(err, expRe) {
+			t.Fatalf("expected error %q, got %v", expRe, err)
+		}
+	})
+
+	t.Run("bump-fails", func(t *testing.T) {
+		h := New(ClusterConfig{
+			NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+			Dialer:       NoopDialer{},
+		})
+		withFakeMigrationClients(h, fakeMigrationClient{
+			bumpClusterVersion: func(id roachpb.NodeID, _ clusterversion.ClusterVersion) error {
+				if id == 3 {
+					return errors.New("injected bump failure")
+				}
+				return nil
+			},
+		})
+		// The confirmation pass is not reached, as getClusterVersion is unset
+		// and would panic.
+		if err := h.BumpClusterVersion(ctx, newCV); !testutils.IsError(err, "injected bump failure") {
+			t.Fatalf("expected injected error, got %v", err)
+		}
+	})
+}
+
+func TestForEveryNodeRetriesNodeListing(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	const numNodes = 3
+	retryOpts := retry.Options{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1.0,
+		MaxRetries:     3,
+	}
+
+	t.Run("fails-once-then-succeeds", func(t *testing.T) {
+		nl := &flakyNodeVitality{
+			NodeVitalityInterface: livenesspb.TestCreateNodeVitality(1, 2, 3),
+			failures:              1,
+		}
+		h := New(ClusterConfig{
+			NodeLiveness:            nl,
+			Dialer:                  NoopDialer{},
+			NodeListingRetryOptions: &retryOpts,
+		})
+
+		var mu syncutil.Mutex
+		opCount := 0
+		if err := h.ForEveryNodeOrServer(ctx, "dummy-op", func(
+			context.Context, serverpb.MigrationClient,
+		) error {
+			mu.Lock()
+			defer mu.Unlock()
+			opCount++
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if opCount != numNodes {
+			t.Fatalf("expected closure to be invoked %d times, got %d", numNodes, opCount)
+		}
+	})
+
+	t.Run("without-retries", func(t *testing.T) {
+		nl := &flakyNodeVitality{
+			NodeVitalityInterface: livenesspb.TestCreateNodeVitality(1, 2, 3),
+			failures:              1,
+		}
+		h := New(ClusterConfig{
+			NodeLiveness: nl,
+			Dialer:       NoopDialer{},
+		})
+
+		expRe := "injected node liveness scan failure"
+		if err := h.ForEveryNodeOrServer(ctx, "dummy-op", func(
+			context.Context, serverpb.MigrationClient,
+		) error {
+			t.Error("unexpected invocation of closure")
+			return errors.New("unexpected invocation of closure")
+		}); !testutils.IsError(err, expRe) {
+			t.Fatalf("expected error %q, got %v", expRe, err)
+		}
+	})
+
+	t.Run("retries-exhausted", func(t *testing.T) {
+		nl := &flakyNodeVitality{
+			NodeVitalityInterface: livenesspb.TestCreateNodeVitality(1, 2, 3),
+			failures:              retryOpts.MaxRetries + 1,
+		}
+		h := New(ClusterConfig{
+			NodeLiveness:            nl,
+			Dialer:                  NoopDialer{},
+			NodeListingRetryOptions: &retryOpts,
+		})
+
+		expRe := "listing nodes: injected node liveness scan failure"
+		if err := h.ForEveryNodeOrServer(ctx, "dummy-op", func(
+			context.Context, serverpb.MigrationClient,
+		) error {
+			t.Error("unexpected invocation of closure")
+			return errors.New("unexpected invocation of closure")
+		}); !testutils.IsError(err, expRe) {
+			t.Fatalf("expected error %q, got %v", expRe, err)
+		}
+	})
+}
+
+func TestForEveryNodeLogsOnlyChangesOnRetry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const numNodes = 3
+
+	// Add a node during the first round, forcing a second round against the
+	// new set of nodes.
+	tc := livenesspb.TestCreateNodeVitality(1, 2, 3)
+	h := New(ClusterConfig{
+		NodeLiveness: tc,
+		Dialer:       NoopDialer{},
+	})
+	var mu syncutil.Mutex
+	opCount := 0
+	if err := h.UntilClusterStable(ctx, retry.Options{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     100 * time.Millisecond,
+		Multiplier:     1.0,
+		MaxRetries:     10,
+	}, func() error {
+		return h.ForEveryNodeOrServer(ctx, "logged-op", func(
+			context.Context, serverpb.MigrationClient,
+		) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			opCount++
+			if opCount == numNodes {
+				tc.AddNextNode()
+			}
+			return nil
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	log.FlushFiles()
+	entries, err := log.FetchEntriesFromFiles(0, math.MaxInt64, 100,
+		regexp.MustCompile(`executing logged-op`),
+		log.SelectEditMode(false /* redact */, false /* keepRedactable */))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msgs []string
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+	}
+	// Entries are returned most recent first.
+	sort.Strings(msgs)
+	exp := []string{
+		"executing logged-op on nodes n{1,2,3}",
+		"re-executing logged-op, nodes changed: [n4 joined the cluster]",
+	}
+	if len(msgs) != len(exp) {
+		t.Fatalf("expected log messages %q, got %q", exp, msgs)
+	}
+	for i := range exp {
+		if !strings.HasSuffix(msgs[i], exp[i]) {
+			t.Fatalf("expected log messages %q, got %q", exp, msgs)
+		}
+	}
+}
+
+func TestEveryNodeTolerant(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	const numNodes = 4
+	const maxFailures = 2
+
+	for _, tc := range []struct {
+		name     string
+		failures int
+		expErr   string
+	}{
+		{name: "no-failures", failures: 0},
+		{name: "at-threshold", failures: maxFailures},
+		{
+			name:     "above-threshold",
+			failures: maxFailures + 1,
+			expErr:   "dummy-op failed on more than 2 nodes: n[0-9]: injected failure",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := New(ClusterConfig{
+				NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3, 4),
+				Dialer:       NoopDialer{},
+			})
+
+			var mu syncutil.Mutex
+			opCount := 0
+			err := h.EveryNodeTolerant(ctx, "dummy-op", maxFailures, func(
+				context.Context, serverpb.MigrationClient,
+			) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				opCount++
+				if opCount <= tc.failures {
+					return errors.New("injected failure")
+				}
+				return nil
+			})
+			if tc.expErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if opCount != numNodes {
+					t.Fatalf("expected closure to be invoked %d times, got %d", numNodes, opCount)
+				}
+				return
+			}
+			if !testutils.IsError(err, tc.expErr) {
+				t.Fatalf("expected error %q, got %v", tc.expErr, err)
+			}
+		})
+	}
+}
+
+func TestPurgeOnAllNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name       string
+		staleNodes map[roachpb.NodeID]bool
+		expErr     string
+	}{
+		{name: "all-purged"},
+		{
+			name:       "stale-after-purge",
+			staleNodes: map[roachpb.NodeID]bool{2: true},
+			expErr:     "purge-op: nodes still report stale data after purging: n{2}",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := New(ClusterConfig{
+				NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+				Dialer:       NoopDialer{},
+			})
+			withFakeMigrationClients(h, fakeMigrationClient{})
+
+			var mu syncutil.Mutex
+			purged := make(map[roachpb.NodeID]bool)
+			err := h.PurgeOnAllNodes(ctx, "purge-op", func(
+				_ context.Context, client serverpb.MigrationClient,
+			) error {
+				mu.Lock()
+				defer mu.Unlock()
+				purged[client.(*fakeMigrationClient).nodeID] = true
+				return nil
+			}, func(
+				_ context.Context, client serverpb.MigrationClient,
+			) (bool, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				id := client.(*fakeMigrationClient).nodeID
+				if !purged[id] {
+					t.Errorf("n%d confirmed before being purged", id)
+				}
+				return !tc.staleNodes[id], nil
+			})
+			if tc.expErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else if !testutils.IsError(err, regexp.QuoteMeta(tc.expErr)) {
+				t.Fatalf("expected error %q, got %v", tc.expErr, err)
+			}
+			if len(purged) != 3 {
+				t.Fatalf("expected all 3 nodes to be purged, got %v", purged)
+			}
+		})
+	}
+}
+
+func TestPurgeAndConfirmWithDeadline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	defer func(opts retry.Options) { purgeConfirmRetryOptions = opts }(purgeConfirmRetryOptions)
+	purgeConfirmRetryOptions = retry.Options{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	// purgeAndConfirm purges every node, with n2 only reporting being clean once
+	// it has been polled cleanAfter times.
+	purgeAndConfirm := func(deadline time.Time, cleanAfter int) (Nodes, map[roachpb.NodeID]int, error) {
+		h := New(ClusterConfig{
+			NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+			Dialer:       NoopDialer{},
+		})
+		withFakeMigrationClients(h, fakeMigrationClient{})
+
+		var mu syncutil.Mutex
+		polls := make(map[roachpb.NodeID]int)
+		stale, err := h.PurgeAndConfirmWithDeadline(ctx, "purge-op", deadline, func(
+			context.Context, serverpb.MigrationClient,
+		) error {
+			return nil
+		}, func(
+			_ context.Context, client serverpb.MigrationClient,
+		) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			id := client.(*fakeMigrationClient).nodeID
+			polls[id]++
+			return id != 2 || polls[id] > cleanAfter, nil
+		})
+		return stale, polls, err
+	}
+
+	t.Run("clears-after-polls", func(t *testing.T) {
+		stale, polls, err := purgeAndConfirm(timeutil.Now().Add(time.Minute), 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stale) != 0 {
+			t.Fatalf("expected no stale nodes, got %s", stale)
+		}
+		// Nodes that reported being clean are not polled again.
+		if exp := map[roachpb.NodeID]int{1: 1, 2: 4, 3: 1}; !reflect.DeepEqual(exp, polls) {
+			t.Fatalf("expected polls %v, got %v", exp, polls)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		stale, _, err := purgeAndConfirm(timeutil.Now(), math.MaxInt)
+		expErr := "purge-op: nodes still report stale data at the deadline"
+		if !testutils.IsError(err, regexp.QuoteMeta(expErr)) {
+			t.Fatalf("expected error %q, got %v", expErr, err)
+		}
+		if len(stale) != 1 || stale[0].ID != 2 {
+			t.Fatalf("expected n2 to be stale, got %s", stale)
+		}
+	})
+}
+
+func TestForEveryNodeOrServerSkipIf(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	newCluster := func() *Cluster {
+		h := New(ClusterConfig{
+			NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3, 4),
+			Dialer:       NoopDialer{},
+		})
+		withFakeMigrationClients(h, fakeMigrationClient{})
+		return h
+	}
+	nodeID := func(client serverpb.MigrationClient) roachpb.NodeID {
+		return client.(*fakeMigrationClient).nodeID
+	}
+
+	t.Run("skips-applied-nodes", func(t *testing.T) {
+		// Even numbered nodes report that they already applied the op.
+		var mu syncutil.Mutex
+		var applied []roachpb.NodeID
+		if err := newCluster().ForEveryNodeOrServerSkipIf(ctx, "dummy-op", func(
+			_ context.Context, client serverpb.MigrationClient,
+		) (bool, error) {
+			return nodeID(client)%2 == 0, nil
+		}, func(_ context.Context, client serverpb.MigrationClient) error {
+			mu.Lock()
+			defer mu.Unlock()
+			applied = append(applied, nodeID(client))
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(applied, func(i, j int) bool { return applied[i] < applied[j] })
+		if exp := []roachpb.NodeID{1, 3}; !reflect.DeepEqual(exp, applied) {
+			t.Fatalf("expected closure to be run on %v, got %v", exp, applied)
+		}
+	})
+
+	t.Run("probe-error", func(t *testing.T) {
+		expRe := "probing whether dummy-op was already applied on n2: injected probe failure"
+		if err := newCluster().ForEveryNodeOrServerSkipIf(ctx, "dummy-op", func(
+			_ context.Context, client serverpb.MigrationClient,
+		) (bool, error) {
+			if nodeID(client) == 2 {
+				return false, errors.New("injected probe failure")
+			}
+			return true, nil
+		}, func(context.Context, serverpb.MigrationClient) error {
+			t.Error("unexpected invocation of closure")
+			return nil
+		}); !testutils.IsError(err, expRe) {
+			t.Fatalf("expected error %q, got %v", expRe, err)
+		}
+	})
+
+	t.Run("closure-error", func(t *testing.T) {
+		expRe := "^injected closure failure$"
+		if err := newCluster().ForEveryNodeOrServerSkipIf(ctx, "dummy-op", func(
+			context.Context, serverpb.MigrationClient,
+		) (bool, error) {
+			return false, nil
+		}, func(context.Context, serverpb.MigrationClient) error {
+			return errors.New("injected closure failure")
+		}); !testutils.IsError(err, expRe) {
+			t.Fatalf("expected error %q, got %v", expRe, err)
+		}
+	})
+}
+
+// countingNodeVitality wraps a NodeVitalityInterface, counting the scans of
+// node liveness records.
+type countingNodeVitality struct {
+	livenesspb.NodeVitalityInterface
+
+	mu    syncutil.Mutex
+	scans int
+}
+
+func (c *countingNodeVitality) ScanNodeVitalityFromKV(
+	ctx context.Context,
+) (livenesspb.NodeVitalityMap, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scans++
+	return c.NodeVitalityInterface.ScanNodeVitalityFromKV(ctx)
+}
+
+func (c *countingNodeVitality) numScans() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.scans
+}
+
+func TestEveryNodeUsing(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	nl := &countingNodeVitality{NodeVitalityInterface: livenesspb.TestCreateNodeVitality(1, 2, 3)}
+	h := New(ClusterConfig{
+		NodeLiveness: nl,
+		Dialer:       NoopDialer{},
+	})
+	withFakeMigrationClients(h, fakeMigrationClient{})
+
+	ns, err := h.StableNodes(ctx, retry.Options{MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []roachpb.NodeID
+	for _, n := range ns {
+		ids = append(ids, n.ID)
+	}
+	if exp := []roachpb.NodeID{1, 2, 3}; !reflect.DeepEqual(exp, ids) {
+		t.Fatalf("expected nodes %v, got %v", exp, ids)
+	}
+	scans := nl.numScans()
+
+	// Run several passes against the stable set of nodes; none of them should
+	// list the nodes again.
+	for i := 0; i < 3; i++ {
+		var mu syncutil.Mutex
+		var ran []roachpb.NodeID
+		if err := h.EveryNodeUsing(ctx, "dummy-op", ns, func(
+			_ context.Context, client serverpb.MigrationClient,
+		) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ran = append(ran, client.(*fakeMigrationClient).nodeID)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(ran, func(i, j int) bool { return ran[i] < ran[j] })
+		if exp := []roachpb.NodeID{1, 2, 3}; !reflect.DeepEqual(exp, ran) {
+			t.Fatalf("expected closure to be run on %v, got %v", exp, ran)
+		}
+	}
+	if n := nl.numScans(); n != scans {
+		t.Fatalf("expected no additional node listings, got %d", n-scans)
+	}
+}
+
+func TestNodeDescriptorsCached(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	vitality := livenesspb.TestCreateNodeVitality(1, 2, 3)
+	vitality.Decommissioned(3, false)
+	nl := &countingNodeVitality{NodeVitalityInterface: vitality}
+	h := New(ClusterConfig{NodeLiveness: nl})
+
+	var fetches int
+	h.scanNodeDescriptors = func(context.Context) ([]roachpb.NodeDescriptor, error) {
+		fetches++
+		var descs []roachpb.NodeDescriptor
+		for _, id := range []roachpb.NodeID{3, 2, 1} {
+			descs = append(descs, roachpb.NodeDescriptor{
+				NodeID:   id,
+				Locality: roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: id.String()}}},
+			})
+		}
+		return descs, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		descs, err := h.NodeDescriptors(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []roachpb.NodeID
+		for _, desc := range descs {
+			ids = append(ids, desc.NodeID)
+			if exp := desc.NodeID.String(); desc.Locality.Tiers[0].Value != exp {
+				t.Fatalf("expected locality region=%s for n%d, got %s", exp, desc.NodeID, desc.Locality)
+			}
+		}
+		if exp := []roachpb.NodeID{1, 2}; !reflect.DeepEqual(exp, ids) {
+			t.Fatalf("expected descriptors for %v, got %v", exp, ids)
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("expected descriptors to be fetched once, got %d", fetches)
+	}
+	if n := nl.numScans(); n != 1 {
+		t.Fatalf("expected nodes to be listed once, got %d", n)
+	}
+}
+
+func TestEveryNodeByLocality(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	regions := map[roachpb.NodeID]string{1: "us-west", 2: "us-east", 3: "us-west", 4: "us-east"}
+	h := New(ClusterConfig{
+		NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3, 4, 5),
+		Dialer:       NoopDialer{},
+	})
+	withFakeMigrationClients(h, fakeMigrationClient{})
+	h.scanNodeDescriptors = func(context.Context) ([]roachpb.NodeDescriptor, error) {
+		var descs []roachpb.NodeDescriptor
+		for id := roachpb.NodeID(1); id <= 5; id++ {
+			desc := roachpb.NodeDescriptor{NodeID: id}
+			if region, ok := regions[id]; ok {
+				desc.Locality = roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: region}}}
+			}
+			descs = append(descs, desc)
+		}
+		return descs, nil
+	}
+
+	// run runs the closure by region, returning the nodes in the order it ran
+	// against them.
+	run := func(failOn roachpb.NodeID) ([]roachpb.NodeID, error) {
+		var mu syncutil.Mutex
+		var ran []roachpb.NodeID
+		err := h.EveryNodeByLocality(ctx, "dummy-op", "region", func(
+			_ context.Context, client serverpb.MigrationClient,
+		) error {
+			mu.Lock()
+			defer mu.Unlock()
+			id := client.(*fakeMigrationClient).nodeID
+			ran = append(ran, id)
+			if id == failOn {
+				return errors.Newf("injected failure on n%d", id)
+			}
+			return nil
+		})
+		return ran, err
+	}
+	// groups splits the nodes the closure ran against into groups of the given
+	// sizes. The order within a group is not deterministic, so each is sorted.
+	groups := func(ran []roachpb.NodeID, sizes ...int) [][]roachpb.NodeID {
+		var gs [][]roachpb.NodeID
+		for _, size := range sizes {
+			if len(ran) < size {
+				t.Fatalf("expected a group of %d nodes, only %v left", size, ran)
+			}
+			g := append([]roachpb.NodeID(nil), ran[:size]...)
+			sort.Slice(g, func(i, j int) bool { return g[i] < g[j] })
+			gs, ran = append(gs, g), ran[size:]
+		}
+		if len(ran) > 0 {
+			t.Fatalf("unexpected nodes %v", ran)
+		}
+		return gs
+	}
+
+	ran, err := run(0 /* failOn */)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The node without a region runs first, followed by the regions in order.
+	if exp, got := [][]roachpb.NodeID{{5}, {2, 4}, {1, 3}}, groups(ran, 1, 2, 2); !reflect.DeepEqual(exp, got) {
+		t.Fatalf("expected groups %v, got %v", exp, got)
+	}
+
+	// A failure in a group stops the groups after it from running.
+	ran, err = run(2 /* failOn */)
+	if !testutils.IsError(err, `running dummy-op on nodes with region=us-east: .*injected failure on n2`) {
+		t.Fatalf("expected injected failure, got %v", err)
+	}
+	if exp, got := [][]roachpb.NodeID{{5}, {2, 4}}, groups(ran, 1, 2); !reflect.DeepEqual(exp, got) {
+		t.Fatalf("expected groups %v, got %v", exp, got)
+	}
+}
+
+func TestEveryNodeByLocalityUntilStable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	var mu syncutil.Mutex
+	regions := map[roachpb.NodeID]string{1: "us-west", 2: "us-east", 3: "us-west"}
+	vitality := livenesspb.TestCreateNodeVitality(1, 2, 3)
+	h := New(ClusterConfig{
+		NodeLiveness: vitality,
+		Dialer:       NoopDialer{},
+	})
+	withFakeMigrationClients(h, fakeMigrationClient{})
+	h.scanNodeDescriptors = func(context.Context) ([]roachpb.NodeDescriptor, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		var descs []roachpb.NodeDescriptor
+		for id, region := range regions {
+			descs = append(descs, roachpb.NodeDescriptor{
+				NodeID:   id,
+				Locality: roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: region}}},
+			})
+		}
+		return descs, nil
+	}
+
+	var ran []roachpb.NodeID
+	changed := false
+	if err := h.EveryNodeByLocalityUntilStable(ctx, "dummy-op", "region", retry.Options{
+		InitialBackoff: time.Millisecond,
+		MaxRetries:     3,
+	}, func(_ context.Context, client serverpb.MigrationClient) error {
+		mu.Lock()
+		defer mu.Unlock()
+		id := client.(*fakeMigrationClient).nodeID
+		ran = append(ran, id)
+		// n2 is alone in its group during the first round. While it runs, n4
+		// joins the cluster and n1 moves regions, forcing a second round.
+		if id == 2 && !changed {
+			changed = true
+			vitality.AddNode(4)
+			regions[1], regions[4] = "us-east", "us-west"
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second round groups the nodes by their updated regions.
+	var got [][]roachpb.NodeID
+	for _, size := range []int{1, 2, 2, 2} {
+		if len(ran) < size {
+			t.Fatalf("expected a group of %d nodes, only %v left", size, ran)
+		}
+		g := append([]roachpb.NodeID(nil), ran[:size]...)
+		sort.Slice(g, func(i, j int) bool { return g[i] < g[j] })
+		got, ran = append(got, g), ran[size:]
+	}
+	if len(ran) > 0 {
+		t.Fatalf("unexpected nodes %v", ran)
+	}
+	exp := [][]roachpb.NodeID{{2}, {1, 3}, {1, 2}, {3, 4}}
+	if !reflect.DeepEqual(exp, got) {
+		t.Fatalf("expected groups %v, got %v", exp, got)
+	}
+}
+
+func TestEveryNewNode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	var mu syncutil.Mutex
+	vitality := livenesspb.TestCreateNodeVitality(1, 2, 3)
+	h := New(ClusterConfig{
+		NodeLiveness: vitality,
+		Dialer:       NoopDialer{},
+	})
+	withFakeMigrationClients(h, fakeMigrationClient{})
+
+	// n1 and n2 were already handled; n3 joined since.
+	initial := Nodes{{ID: 1, Epoch: 1}, {ID: 2, Epoch: 1}}
+	var ran []roachpb.NodeID
+	stable, err := h.EveryNewNode(ctx, "dummy-op", initial, retry.Options{
+		InitialBackoff: time.Millisecond,
+		MaxRetries:     3,
+	}, func(_ context.Context, client serverpb.MigrationClient) error {
+		mu.Lock()
+		defer mu.Unlock()
+		id := client.(*fakeMigrationClient).nodeID
+		ran = append(ran, id)
+		// n4 joins while the closure runs against n3, and is handled in the
+		// next round.
+		if id == 3 {
+			vitality.AddNode(4)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := []roachpb.NodeID{3, 4}; !reflect.DeepEqual(exp, ran) {
+		t.Fatalf("expected closure to run on %v, ran on %v", exp, ran)
+	}
+	var got []roachpb.NodeID
+	for _, n := range stable {
+		got = append(got, n.ID)
+	}
+	if exp := []roachpb.NodeID{1, 2, 3, 4}; !reflect.DeepEqual(exp, got) {
+		t.Fatalf("expected stable nodes %v, got %v", exp, got)
+	}
+}
+
+func TestEveryNodeTimed(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	h := New(ClusterConfig{
+		NodeLiveness: livenesspb.TestCreateNodeVitality(1, 2, 3),
+		Dialer:       NoopDialer{},
+	})
+	withFakeMigrationClients(h, fakeMigrationClient{})
+
+	// Each node takes longer than the last, so that the timings can be told
+	// apart.
+	delay := func(id roachpb.NodeID) time.Duration {
+		return time.Duration(id) * 10 * time.Millisecond
+	}
+	timings, err := h.EveryNodeTimed(ctx, "dummy-op", func(
+		_ context.Context, client serverpb.MigrationClient,
+	) error {
+		time.Sleep(delay(client.(*fakeMigrationClient).nodeID))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timings) != 3 {
+		t.Fatalf("expected timings for 3 nodes, got %v", timings)
+	}
+	for id := roachpb.NodeID(1); id <= 3; id++ {
+		took, ok := timings[id]
+		if !ok {
+			t.Fatalf("expected a timing for n%d, got %v", id, timings)
+		}
+		if took < delay(id) {
+			t.Fatalf("expected n%d to take at least %s, got %s", id, delay(id), took)
+		}
+	}
+
+	// Timings are still returned for the nodes the closure completed on when it
+	// fails on one of them.
+	timings, err = h.EveryNodeTimed(ctx, "dummy-op", func(
+		_ context.Context, client serverpb.MigrationClient,
+	) error {
+		if id := client.(*fakeMigrationClient).nodeID; id == 2 {
+			return errors.Newf("injected failure on n%d", id)
+		}
+		return nil
+	})
+	if !testutils.IsError(err, "injected failure on n2") {
+		t.Fatalf("expected injected failure, got %v", err)
+	}
+	if _, ok := timings[2]; !ok {
+		t.Fatalf("expected a timing for the failed node, got %v", timings)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgradecluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// migrationLockTTL is how long a migration lock remains held without being
+// extended by its holder. Holders extend their lock at a third of this
+// interval, so a coordinator that dies releases its lock once it elapses.
+var migrationLockTTL = time.Minute
+
+// migrationLockReleaseTimeout bounds releasing a migration lock, which is done
+// even once the context of the WithMigrationLock call is canceled.
+var migrationLockReleaseTimeout = 10 * time.Second
+
+// migrationLock is the value stored at a migration lock's key.
+type migrationLock struct {
+	// holder identifies the WithMigrationLock call holding the lock.
+	holder uuid.UUID
+	// expiration is the time after which the lock may be taken over. It is
+	// derived from the HLC timestamp of the transaction that wrote the lock, so
+	// that coordinators compare it against the same clock regardless of any
+	// skew between their nodes' wall clocks.
+	expiration hlc.Timestamp
+}
+
+func (l migrationLock) encode() []byte {
+	return encoding.EncodeVarintAscending(l.holder.GetBytes(), l.expiration.WallTime)
+}
+
+func decodeMigrationLock(b []byte) (migrationLock, error) {
+	if len(b) < uuid.Size {
+		return migrationLock{}, errors.AssertionFailedf("malformed migration lock %x", b)
+	}
+	holder, err := uuid.FromBytes(b[:uuid.Size])
+	if err != nil {
+		return migrationLock{}, err
+	}
+	_, nanos, err := encoding.DecodeVarintAscending(b[uuid.Size:])
+	if err != nil {
+		return migrationLock{}, err
+	}
+	return migrationLock{holder: holder, expiration: hlc.Timestamp{WallTime: nanos}}, nil
+}
+
+// migrationLockKey returns the key at which the lock with the given name is
+// stored.
+func migrationLockKey(name string) roachpb.Key {
+	prefix := keys.MigrationLockPrefix
+	return append(prefix[:len(prefix):len(prefix)], name...)
+}
+
+// WithMigrationLock runs fn while holding the cluster-wide lock with the given
+// name, ensuring that a single coordinator runs it at a time, including across
+// restarts. It returns an error without running fn if another coordinator
+// holds the lock. The lock is extended while fn runs and released once it
+// returns; if extending it fails, the context passed to fn is canceled. A lock
+// whose holder died is taken over once it expires.
+func (c *Cluster) WithMigrationLock(
+	ctx context.Context, name string, fn func(context.Context) error,
+) error {
+	key := migrationLockKey(name)
+	holder := uuid.MakeV4()
+	if err := c.acquireMigrationLock(ctx, name, key, holder); err != nil {
+		return err
+	}
+	defer func() {
+		// The lock is released even if ctx was canceled, so that it does not
+		// linger until it expires.
+		if err := timeutil.RunWithTimeout(context.WithoutCancel(ctx), "releasing migration lock",
+			migrationLockReleaseTimeout, func(ctx context.Context) error {
+				return c.releaseMigrationLock(ctx, key, holder)
+			}); err != nil {
+			log.Warningf(ctx, "failed to release migration lock %q: %v", name, err)
+		}
+	}()
+
+	done := make(chan struct{})
+	grp := ctxgroup.WithContext(ctx)
+	grp.GoCtx(func(ctx context.Context) error {
+		ticker := time.NewTicker(migrationLockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+				if err := c.acquireMigrationLock(ctx, name, key, holder); err != nil {
+					return errors.Wrapf(err, "extending migration lock %q", name)
+				}
+			}
+		}
+	})
+	grp.GoCtx(func(ctx context.Context) error {
+		defer close(done)
+		return fn(ctx)
+	})
+	return grp.Wait()
+}
+
+// acquireMigrationLock writes the lock at key on behalf of holder, expiring
+// migrationLockTTL after the transaction's read timestamp. It fails if the lock
+// is held, and not yet expired as of that timestamp, by another holder. Holders
+// also call it to extend their lock.
+func (c *Cluster) acquireMigrationLock(
+	ctx context.Context, name string, key roachpb.Key, holder uuid.UUID,
+) error {
+	return c.c.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		res, err := txn.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		now := txn.ReadTimestamp()
+		if res.Exists() {
+			existing, err := decodeMigrationLock(res.ValueBytes())
+			if err != nil {
+				return err
+			}
+			if existing.holder != holder && now.Less(existing.expiration) {
+				return errors.Newf("migration lock %q is held by another coordinator until %s",
+					name, existing.expiration.GoTime())
+			}
+		}
+		lock := migrationLock{holder: holder, expiration: now.AddDuration(migrationLockTTL)}
+		return txn.Put(ctx, key, lock.encode())
+	})
+}
+
+// releaseMigrationLock deletes the lock at key, if it is still held by holder.
+func (c *Cluster) releaseMigrationLock(
+	ctx context.Context, key roachpb.Key, holder uuid.UUID,
+) error {
+	return c.c.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		res, err := txn.Get(ctx, key)
+		if err != nil || !res.Exists() {
+			return err
+		}
+		existing, err := decodeMigrationLock(res.ValueBytes())
+		if err != nil {
+			return err
+		}
+		if existing.holder != holder {
+			return nil
+		}
+		_, err = txn.Del(ctx, key)
+		return err
+	})
+}
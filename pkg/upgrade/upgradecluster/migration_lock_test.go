@@ -0,0 +1,105 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgradecluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMigrationLock(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s := serverutils.StartServerOnly(t, base.TestServerArgs{
+		DefaultTestTenant: base.TestIsSpecificToStorageLayerAndNeedsASystemTenant,
+	})
+	defer s.Stopper().Stop(ctx)
+	c := New(ClusterConfig{DB: s.DB()})
+
+	t.Run("held", func(t *testing.T) {
+		acquired, release := make(chan struct{}), make(chan struct{})
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- c.WithMigrationLock(ctx, "held", func(context.Context) error {
+				close(acquired)
+				<-release
+				return nil
+			})
+		}()
+		<-acquired
+
+		// A second coordinator is refused while the first holds the lock.
+		ran := false
+		err := c.WithMigrationLock(ctx, "held", func(context.Context) error {
+			ran = true
+			return nil
+		})
+		require.ErrorContains(t, err, `migration lock "held" is held by another coordinator`)
+		require.False(t, ran)
+
+		// Locks with other names are unaffected.
+		require.NoError(t, c.WithMigrationLock(ctx, "other", func(context.Context) error {
+			return nil
+		}))
+
+		close(release)
+		require.NoError(t, <-errCh)
+
+		// Once released, the lock can be acquired again.
+		require.NoError(t, c.WithMigrationLock(ctx, "held", func(context.Context) error {
+			ran = true
+			return nil
+		}))
+		require.True(t, ran)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		// A coordinator that died left its lock behind, which has since expired.
+		dead := migrationLock{holder: uuid.MakeV4(), expiration: s.Clock().Now().AddDuration(-time.Second)}
+		key := migrationLockKey("expired")
+		require.NoError(t, s.DB().Put(ctx, key, dead.encode()))
+
+		ran := false
+		require.NoError(t, c.WithMigrationLock(ctx, "expired", func(context.Context) error {
+			ran = true
+			return nil
+		}))
+		require.True(t, ran)
+
+		res, err := s.DB().Get(ctx, key)
+		require.NoError(t, err)
+		require.False(t, res.Exists())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		// The lock is released even if the context of the call is canceled.
+		ctx, cancel := context.WithCancel(ctx)
+		err := c.WithMigrationLock(ctx, "canceled", func(context.Context) error {
+			cancel()
+			return ctx.Err()
+		})
+		require.ErrorIs(t, err, context.Canceled)
+
+		res, err := s.DB().Get(context.Background(), migrationLockKey("canceled"))
+		require.NoError(t, err)
+		require.False(t, res.Exists())
+	})
+}
@@ -33,7 +33,9 @@ type Nodes []Node
 // currently part of the cluster (i.e. they haven't been decommissioned away)
 // and any nodes which are currently unavailable. Migrations have the
 // pre-requisite that all nodes are up and running so that we're able to
-// execute all relevant node-level operations on them.
+// execute all relevant node-level operations on them. Both lists are sorted by
+// node ID, so that operations run against them in a deterministic order,
+// regardless of the order in which the liveness records were scanned.
 //
 // It's important to note that this makes no guarantees about new nodes
 // being added to the cluster. It's entirely possible for that to happen
@@ -58,7 +60,7 @@ func NodesFromNodeLiveness(
 		live = append(live, Node{ID: id, Epoch: n.GenLiveness().Epoch})
 
 	}
-	// Tests assume the nodes are sorted, so sort by node id first.
+	// The records are scanned into a map, so sort by node ID.
 	sort.Slice(live, func(i, j int) bool { return live[i].ID < live[j].ID })
 	sort.Slice(unavailable, func(i, j int) bool { return unavailable[i].ID < unavailable[j].ID })
 	return live, unavailable, nil
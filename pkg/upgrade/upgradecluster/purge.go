@@ -0,0 +1,150 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgradecluster
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+)
+
+// PurgeOnAllNodes codifies the cleanup half of the two-phase upgrade pattern
+// described on upgrade.Cluster.UntilClusterStable: once stale data is no longer
+// being created, every node is told to purge it, after which every node is
+// asked to confirm that it is gone.
+//
+// purge is first run against every node in the cluster. Once it has succeeded
+// everywhere, confirm is run against the same set of nodes, and is expected
+// to return true if the node no longer has any stale data. An error naming the
+// offending nodes is returned if any of them still report stale data. As with
+// ForEveryNodeOrServer, this is expected to be used in conjunction with
+// UntilClusterStable.
+func (c *Cluster) PurgeOnAllNodes(
+	ctx context.Context,
+	op string,
+	purge func(context.Context, serverpb.MigrationClient) error,
+	confirm func(context.Context, serverpb.MigrationClient) (bool, error),
+) error {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.forEveryNode(ctx, op, live, func(
+		ctx context.Context, _ Node, client serverpb.MigrationClient,
+	) error {
+		return purge(ctx, client)
+	}); err != nil {
+		return err
+	}
+
+	var mu syncutil.Mutex
+	var stale Nodes
+	if err := c.forEveryNode(ctx, op+"-confirm", live, func(
+		ctx context.Context, node Node, client serverpb.MigrationClient,
+	) error {
+		purged, err := confirm(ctx, client)
+		if err != nil {
+			return err
+		}
+		if !purged {
+			mu.Lock()
+			defer mu.Unlock()
+			stale = append(stale, node)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(stale) > 0 {
+		sort.Slice(stale, func(i, j int) bool { return stale[i].ID < stale[j].ID })
+		return errors.Newf("%s: nodes still report stale data after purging: %s",
+			redact.Safe(op), stale)
+	}
+	return nil
+}
+
+// purgeConfirmRetryOptions controls how often PurgeAndConfirmWithDeadline polls
+// the nodes that still report stale data.
+var purgeConfirmRetryOptions = retry.Options{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// PurgeAndConfirmWithDeadline is like PurgeOnAllNodes, but accommodates purges
+// that complete asynchronously, e.g. ones that rely on compactions to remove
+// the stale data. Once purge has succeeded on every node, confirm is polled
+// against the nodes that still report stale data until all of them report
+// being clean, or until the deadline elapses. In the latter case, the nodes
+// still reporting stale data are returned, sorted by node ID, along with an
+// error naming them.
+func (c *Cluster) PurgeAndConfirmWithDeadline(
+	ctx context.Context,
+	op string,
+	deadline time.Time,
+	purge func(context.Context, serverpb.MigrationClient) error,
+	confirm func(context.Context, serverpb.MigrationClient) (bool, error),
+) (Nodes, error) {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.forEveryNode(ctx, op, live, func(
+		ctx context.Context, _ Node, client serverpb.MigrationClient,
+	) error {
+		return purge(ctx, client)
+	}); err != nil {
+		return nil, err
+	}
+
+	stale := live
+	for r := retry.StartWithCtx(ctx, purgeConfirmRetryOptions); r.Next(); {
+		var mu syncutil.Mutex
+		var stillStale Nodes
+		if err := c.forEveryNode(ctx, op+"-confirm", stale, func(
+			ctx context.Context, node Node, client serverpb.MigrationClient,
+		) error {
+			purged, err := confirm(ctx, client)
+			if err != nil {
+				return err
+			}
+			if !purged {
+				mu.Lock()
+				defer mu.Unlock()
+				stillStale = append(stillStale, node)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		sort.Slice(stillStale, func(i, j int) bool { return stillStale[i].ID < stillStale[j].ID })
+		stale = stillStale
+		if len(stale) == 0 {
+			return nil, nil
+		}
+		if !timeutil.Now().Before(deadline) {
+			return stale, errors.Newf("%s: nodes still report stale data at the deadline of %s: %s",
+				redact.Safe(op), deadline, stale)
+		}
+		log.VEventf(ctx, 2, "%s: waiting for nodes to finish purging: %s", redact.Safe(op), stale)
+	}
+	return stale, ctx.Err()
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgradecluster
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// pendingSchemaChangesQuery lists the schema change jobs, of either schema
+// changer, that have not reached a terminal state and whose descriptors
+// overlap with the given ones.
+const pendingSchemaChangesQuery = `
+SELECT job_id
+  FROM crdb_internal.jobs
+ WHERE job_type IN ($1, $2)
+   AND status IN ` + jobs.NonTerminalStatusTupleString + `
+   AND descriptor_ids && $3
+ ORDER BY job_id`
+
+// NoPendingSchemaChangesFor reports whether no schema change job is pending for
+// any of the given tables, letting migrations that must not race with schema
+// changes gate on it. If some are pending, it returns false along with an
+// error naming the blocking jobs.
+func (c *Cluster) NoPendingSchemaChangesFor(
+	ctx context.Context, tableIDs ...descpb.ID,
+) (bool, error) {
+	if c.c.Executor == nil {
+		return false, errors.AssertionFailedf("executor not configured")
+	}
+	if len(tableIDs) == 0 {
+		return true, nil
+	}
+	ids := tree.NewDArray(types.Int)
+	for _, id := range tableIDs {
+		if err := ids.Append(tree.NewDInt(tree.DInt(id))); err != nil {
+			return false, err
+		}
+	}
+	rows, err := c.c.Executor.QueryBufferedEx(ctx, "pending-schema-changes", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride, pendingSchemaChangesQuery,
+		jobspb.TypeSchemaChange.String(), jobspb.TypeNewSchemaChange.String(), ids)
+	if err != nil {
+		return false, errors.Wrap(err, "querying pending schema changes")
+	}
+	if len(rows) == 0 {
+		return true, nil
+	}
+	blocking := make([]jobspb.JobID, 0, len(rows))
+	for _, row := range rows {
+		blocking = append(blocking, jobspb.JobID(tree.MustBeDInt(row[0])))
+	}
+	return false, errors.Newf("schema change jobs %v are pending for tables %v", blocking, tableIDs)
+}
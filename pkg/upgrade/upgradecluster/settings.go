@@ -0,0 +1,101 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package upgradecluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// SettingConsistentAcrossNodes reports whether every node in the cluster
+// observes the same value for the setting with the given key. If they do not,
+// false is returned along with an error naming the nodes that diverge from the
+// value observed by most nodes, and the values they observe instead.
+//
+// Migrations that depend on a setting being applied uniformly can use it to
+// catch nodes that have yet to pick up a change before proceeding.
+func (c *Cluster) SettingConsistentAcrossNodes(
+	ctx context.Context, settingKey string,
+) (bool, error) {
+	live, _, err := c.nodes(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var mu syncutil.Mutex
+	values := make(map[roachpb.NodeID]string, len(live))
+	req := &serverpb.GetSettingValueRequest{Key: settingKey}
+	if err := c.forEveryNode(ctx, "get-setting-value="+settingKey, live, func(
+		ctx context.Context, node Node, client serverpb.MigrationClient,
+	) error {
+		resp, err := client.GetSettingValue(ctx, req)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		values[node.ID] = resp.Value
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	if divergent := formatDivergentSettingValues(values); divergent != "" {
+		return false, errors.Newf("setting %s is not consistent across nodes: %s",
+			settingKey, divergent)
+	}
+	return true, nil
+}
+
+// formatDivergentSettingValues renders the nodes whose value differs from the
+// value observed by most nodes, along with the value each observes, in node
+// ID order. Ties between values are broken in favor of the smaller value. The
+// empty string is returned if every node observes the same value.
+func formatDivergentSettingValues(values map[roachpb.NodeID]string) string {
+	counts := make(map[string]int)
+	for _, v := range values {
+		counts[v]++
+	}
+	if len(counts) <= 1 {
+		return ""
+	}
+	var common string
+	for v, n := range counts {
+		if n > counts[common] || (n == counts[common] && v < common) {
+			common = v
+		}
+	}
+
+	ids := make([]roachpb.NodeID, 0, len(values))
+	for id, v := range values {
+		if v != common {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d of %d nodes observe %q, but ", counts[common], len(values), common)
+	for i, id := range ids {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "n%d observes %q", id, values[id])
+	}
+	return buf.String()
+}